@@ -1,9 +1,11 @@
 package uptimerobot
 
-import "fmt"
+import "context"
 
 // AccountDetail represents detailed information about the account
 type AccountDetail struct {
+	// the e-mail address of the account owner
+	Email string `json:"email"`
 	// the max number of monitors that can be created for the account
 	MonitorLimit int `json:"monitorLimit,string"`
 	// the min monitoring interval (in minutes) supported by the account
@@ -20,19 +22,36 @@ type AccountDetail struct {
 // added and number of up/down/paused monitors) about the account identified by
 // the given APIKey
 func (u *UptimeRobot) GetAccountDetails() (*AccountDetail, error) {
+	return u.GetAccountDetailsContext(context.Background())
+}
+
+// GetAccountDetailsContext is like GetAccountDetails but uses the given context
+// for the request
+func (u *UptimeRobot) GetAccountDetailsContext(ctx context.Context) (*AccountDetail, error) {
 	result := &struct {
-		Stat    string        `json:"stat"`
-		Account AccountDetail `json:"account"`
+		Stat    string `json:"stat"`
+		Account struct {
+			Email           string  `json:"email"`
+			MonitorLimit    flexInt `json:"monitor_limit"`
+			MonitorInterval flexInt `json:"monitor_interval"`
+			UpMonitors      flexInt `json:"up_monitors"`
+			DownMonitors    flexInt `json:"down_monitors"`
+			PausedMonitors  flexInt `json:"paused_monitors"`
+		} `json:"account"`
 	}{}
 
-	err := u.doRequest("getAccountDetails", nil, result)
+	err := u.doRequest(ctx, "getAccountDetails", nil, result)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.Stat == "ok" {
-		return &result.Account, nil
-	}
-
-	return nil, fmt.Errorf("Got unexpected status: %s", result.Stat)
+	a := result.Account
+	return &AccountDetail{
+		Email:           a.Email,
+		MonitorLimit:    int(a.MonitorLimit),
+		MonitorInterval: int(a.MonitorInterval),
+		UpMonitors:      int(a.UpMonitors),
+		DownMonitors:    int(a.DownMonitors),
+		PausedMonitors:  int(a.PausedMonitors),
+	}, nil
 }
@@ -1,6 +1,11 @@
 package uptimerobot
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -39,3 +44,76 @@ func TestGetAccountDetailWithoutAccount(t *testing.T) {
 		t.Errorf("Test errored with unexpected result: %s", err)
 	}
 }
+
+func TestGetAccountDetailContextCanceled(t *testing.T) {
+	ur := New(os.Getenv("UR_API_KEY"))
+	ur.FullDebug = false
+	ur.disableCaching = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ur.GetAccountDetailsContext(ctx)
+	if err == nil {
+		t.Fatalf("Test should have errored.")
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a context.Canceled error, got: %s", err)
+	}
+}
+
+func TestGetAccountDetailBaseURL(t *testing.T) {
+	var gotPath, gotQuery, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotKey = r.PostFormValue("api_key")
+		fmt.Fprint(w, `{"stat":"ok","account":{"email":"test@example.com","monitor_limit":50,"monitor_interval":1,"up_monitors":1,"down_monitors":0,"paused_monitors":0}}`)
+	}))
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL + "/prefix/"
+
+	ad, err := ur.GetAccountDetails()
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if gotPath != "/prefix/getAccountDetails" {
+		t.Errorf("Request was sent to unexpected path: %s", gotPath)
+	}
+
+	if gotQuery != "" || gotKey != "foobar" {
+		t.Errorf("Expected the API-key in the form body only, got query %q and key %q", gotQuery, gotKey)
+	}
+
+	if ad.Email != "test@example.com" || ad.MonitorLimit != 50 || ad.UpMonitors != 1 {
+		t.Errorf("Unexpected account details: %+v", ad)
+	}
+}
+
+func TestGetAccountDetailAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"stat":"fail","error":{"type":"invalid_parameter","parameter_name":"api_key","passed_value":"foobar","message":"apiKey is wrong"}}`)
+	}))
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL
+
+	_, err := ur.GetAccountDetails()
+	if !errors.Is(err, ErrorAPIKeyWrong) {
+		t.Fatalf("Expected ErrorAPIKeyWrong, got: %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an API error, got: %s", err)
+	}
+
+	if apiErr.Method != "getAccountDetails" || apiErr.StatusCode != http.StatusOK || apiErr.ParameterName != "api_key" || apiErr.Message != "apiKey is wrong" {
+		t.Errorf("Got an unexpected API error: %+v", apiErr)
+	}
+}
@@ -1,6 +1,8 @@
 package uptimerobot
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -41,60 +43,94 @@ type AlertContact struct {
 	FriendlyName string             `json:"friendlyname"`
 }
 
+// alertContactJSON is the representation of an alert contact used by the API
+type alertContactJSON struct {
+	ID           flexInt `json:"id"`
+	Type         flexInt `json:"type"`
+	Value        string  `json:"value"`
+	Status       flexInt `json:"status"`
+	Threshold    flexInt `json:"threshold"`
+	Recurrence   flexInt `json:"recurrence"`
+	FriendlyName string  `json:"friendly_name"`
+}
+
+func (jc alertContactJSON) alertContact() AlertContact {
+	return AlertContact{
+		ID:           int(jc.ID),
+		Type:         AlertContactType(jc.Type),
+		Value:        jc.Value,
+		Status:       AlertContactStatus(jc.Status),
+		Threshold:    int(jc.Threshold),
+		Recurrence:   int(jc.Recurrence),
+		FriendlyName: jc.FriendlyName,
+	}
+}
+
 // GetAlertContacts can be used to retrieve a (filtered) list of alert contacts
 func (u *UptimeRobot) GetAlertContacts(contactIDs []int) ([]AlertContact, error) {
-	params := &url.Values{
-		"limit":  []string{"50"},
-		"offset": []string{"0"},
-	}
+	return u.GetAlertContactsContext(context.Background(), contactIDs)
+}
 
-	if len(contactIDs) > 0 {
-		params.Set("alertcontacts", u.buildIntList(contactIDs))
-	}
+// GetAlertContactsContext is like GetAlertContacts but uses the given context
+// for the requests. The context is also checked between the pages of the
+// result.
+func (u *UptimeRobot) GetAlertContactsContext(ctx context.Context, contactIDs []int) ([]AlertContact, error) {
+	it := u.IterateAlertContactsContext(ctx, contactIDs)
 
 	response := []AlertContact{}
+	for it.Next() {
+		response = append(response, it.AlertContact())
+	}
 
-	for {
-		res := &struct {
-			Stat          string `json:"stat"`
-			Offset        int    `json:"offset,string"`
-			Limit         int    `json:"limit,string"`
-			Total         int    `json:"total,string"`
-			AlertContacts struct {
-				Contacts []AlertContact `json:"alertcontact"`
-			} `json:"alertcontacts"`
-		}{}
+	if err := it.Err(); err != nil {
+		return []AlertContact{}, err
+	}
 
-		err := u.doRequest("getAlertContacts", params, res)
-		if err != nil {
-			return []AlertContact{}, err
-		}
+	return response, nil
+}
 
-		for _, i := range res.AlertContacts.Contacts {
-			response = append(response, i)
-		}
+// getAlertContactsPage fetches the page of alert contacts selected by the
+// offset in params
+func (u *UptimeRobot) getAlertContactsPage(ctx context.Context, params *url.Values) ([]AlertContact, pagination, error) {
+	res := &struct {
+		Stat string `json:"stat"`
+		pagination
+		AlertContacts []alertContactJSON `json:"alert_contacts"`
+	}{}
 
-		if res.Limit+res.Offset >= res.Total {
-			break
-		}
+	err := u.doRequest(ctx, "getAlertContacts", params, res)
+	if errors.Is(err, ErrorNoAlertContactsFound) {
+		return []AlertContact{}, pagination{}, nil
+	}
+	if err != nil {
+		return nil, pagination{}, err
+	}
 
-		params.Set("offset", strconv.FormatInt(int64(res.Offset+res.Limit), 10))
+	result := []AlertContact{}
+	for _, jc := range res.AlertContacts {
+		result = append(result, jc.alertContact())
 	}
 
-	return response, nil
+	return result, res.pagination, nil
 }
 
 // NewAlertContact creates a new alert contact of any type (mobile/SMS alert
 // contacts are not supported yet)
 func (u *UptimeRobot) NewAlertContact(in AlertContact) (*AlertContact, error) {
+	return u.NewAlertContactContext(context.Background(), in)
+}
+
+// NewAlertContactContext is like NewAlertContact but uses the given context
+// for the request
+func (u *UptimeRobot) NewAlertContactContext(ctx context.Context, in AlertContact) (*AlertContact, error) {
 	params := &url.Values{}
 	res := &struct {
-		Stat         string       `json:"stat"`
-		Message      string       `json:"message"`
-		AlertContact AlertContact `json:"alertcontact"`
-	}{
-		AlertContact: in,
-	}
+		Stat         string `json:"stat"`
+		AlertContact struct {
+			ID     flexInt `json:"id"`
+			Status flexInt `json:"status"`
+		} `json:"alertcontact"`
+	}{}
 
 	if in.Type == 0 || in.Value == "" {
 		return nil, fmt.Errorf("Required parameters misisng. Please check the documentation.")
@@ -104,42 +140,123 @@ func (u *UptimeRobot) NewAlertContact(in AlertContact) (*AlertContact, error) {
 		return nil, fmt.Errorf("FriendlyName may not have more than 30 chars")
 	}
 
-	params.Set("alertContactType", strconv.FormatInt(int64(in.Type), 10))
-	params.Set("alertContactValue", in.Value)
+	params.Set("type", strconv.FormatInt(int64(in.Type), 10))
+	params.Set("value", in.Value)
 
 	if in.FriendlyName != "" {
-		params.Set("alertContactFriendlyName", in.FriendlyName)
+		params.Set("friendly_name", in.FriendlyName)
 	}
 
-	err := u.doRequest("newAlertContact", params, res)
+	err := u.doRequest(ctx, "newAlertContact", params, res)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Stat != "ok" {
-		return nil, fmt.Errorf("Contact not created: %s", res.Message)
-	}
+	out := in
+	out.ID = int(res.AlertContact.ID)
+	out.Status = AlertContactStatus(res.AlertContact.Status)
+	return &out, nil
+}
 
-	return &res.AlertContact, nil
+// EditAlertContact updates Value, FriendlyName and Status of the alert contact
+// identified by the ID of the input. Empty fields are left unchanged, the type
+// of an alert contact cannot be changed. The ID of the alert contact stays the
+// same, so monitors keep it assigned. The returned alert contact has all
+// fields set, the unchanged ones as they are in the account.
+func (u *UptimeRobot) EditAlertContact(in AlertContact) (*AlertContact, error) {
+	return u.EditAlertContactContext(context.Background(), in)
 }
 
-// DeleteAlertContact can be used to delete an alert contact
-func (u *UptimeRobot) DeleteAlertContact(contactID int) error {
+// EditAlertContactContext is like EditAlertContact but uses the given context
+// for the request
+func (u *UptimeRobot) EditAlertContactContext(ctx context.Context, in AlertContact) (*AlertContact, error) {
+	params := &url.Values{}
 	res := &struct {
 		Stat string `json:"stat"`
 	}{}
 
-	err := u.doRequest("deleteAlertContact", &url.Values{
-		"alertContactID": []string{strconv.FormatInt(int64(contactID), 10)},
-	}, res)
+	if in.ID == 0 {
+		return nil, fmt.Errorf("Required parameters misisng. Please check the documentation.")
+	}
+
+	if len(in.FriendlyName) > 30 {
+		return nil, fmt.Errorf("FriendlyName may not have more than 30 chars")
+	}
+
+	params.Set("id", strconv.FormatInt(int64(in.ID), 10))
+
+	if in.Value != "" {
+		params.Set("value", in.Value)
+	}
+
+	if in.FriendlyName != "" {
+		params.Set("friendly_name", in.FriendlyName)
+	}
+
+	// Alert contacts can only be paused or activated, the activation itself
+	// happens by the owner of the contact
+	if in.Status != AlertContactStatusNotActivated {
+		params.Set("status", strconv.FormatInt(int64(in.Status), 10))
+	}
 
+	err := u.doRequest(ctx, "editAlertContact", params, res)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// The API only reports the ID, the fields left empty are taken from the
+	// current alert contact
+	current, err := u.GetAlertContactsContext(ctx, []int{in.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(current) != 1 {
+		return nil, fmt.Errorf("Alert contact %d not found after editing it", in.ID)
+	}
+
+	out := current[0]
+	if in.Value != "" {
+		out.Value = in.Value
 	}
+	if in.FriendlyName != "" {
+		out.FriendlyName = in.FriendlyName
+	}
+	if in.Status != AlertContactStatusNotActivated {
+		out.Status = in.Status
+	}
+	return &out, nil
+}
 
-	if res.Stat == "ok" {
-		return nil
+// NewOrEditAlertContact creates a new alert contact if you do not pass an ID in
+// the input, otherwise the alert contact is updated
+func (u *UptimeRobot) NewOrEditAlertContact(in AlertContact) (*AlertContact, error) {
+	return u.NewOrEditAlertContactContext(context.Background(), in)
+}
+
+// NewOrEditAlertContactContext is like NewOrEditAlertContact but uses the given
+// context for the request
+func (u *UptimeRobot) NewOrEditAlertContactContext(ctx context.Context, in AlertContact) (*AlertContact, error) {
+	if in.ID == 0 {
+		return u.NewAlertContactContext(ctx, in)
 	}
+	return u.EditAlertContactContext(ctx, in)
+}
+
+// DeleteAlertContact can be used to delete an alert contact
+func (u *UptimeRobot) DeleteAlertContact(contactID int) error {
+	return u.DeleteAlertContactContext(context.Background(), contactID)
+}
+
+// DeleteAlertContactContext is like DeleteAlertContact but uses the given
+// context for the request
+func (u *UptimeRobot) DeleteAlertContactContext(ctx context.Context, contactID int) error {
+	res := &struct {
+		Stat string `json:"stat"`
+	}{}
+
+	err := u.doRequest(ctx, "deleteAlertContact", &url.Values{
+		"id": []string{strconv.FormatInt(int64(contactID), 10)},
+	}, res)
 
-	return fmt.Errorf("Got unexpected status: %s", res.Stat)
+	return err
 }
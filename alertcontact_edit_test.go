@@ -0,0 +1,61 @@
+package uptimerobot_test
+
+import (
+	"errors"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestEditAlertContact(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	ac, err := ur.NewOrEditAlertContact(uptimerobot.AlertContact{
+		Type:         uptimerobot.AlertContactTypeWebHook,
+		Value:        "https://hooks.example.com/old?",
+		FriendlyName: "hook",
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	m := srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName:  "example",
+		URL:           "http://www.example.com/",
+		Type:          uptimerobot.MonitorTypeHTTP,
+		AlertContacts: []uptimerobot.AlertContact{{ID: ac.ID}},
+	})
+
+	edited, err := ur.NewOrEditAlertContact(uptimerobot.AlertContact{
+		ID:     ac.ID,
+		Value:  "https://hooks.example.com/new?",
+		Status: uptimerobot.AlertContactStatusPaused,
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if edited.ID != ac.ID || edited.Type != uptimerobot.AlertContactTypeWebHook || edited.FriendlyName != "hook" || edited.Value != "https://hooks.example.com/new?" || edited.Status != uptimerobot.AlertContactStatusPaused {
+		t.Errorf("Expected the edited alert contact with the unchanged fields, got %+v", edited)
+	}
+
+	acs := srv.AlertContacts()
+	if len(acs) != 1 || acs[0].Value != "https://hooks.example.com/new?" || acs[0].FriendlyName != "hook" || acs[0].Status != uptimerobot.AlertContactStatusPaused {
+		t.Errorf("Alert contact was not edited as expected: %+v", acs)
+	}
+
+	if mons := srv.Monitors(); len(mons[0].AlertContacts) != 1 || mons[0].AlertContacts[0].ID != ac.ID || mons[0].ID != m.ID {
+		t.Errorf("Expected the alert contact to stay assigned: %+v", mons[0].AlertContacts)
+	}
+
+	if _, err := ur.EditAlertContact(uptimerobot.AlertContact{ID: ac.ID}); !errors.Is(err, uptimerobot.ErrorNoEditsFound) {
+		t.Errorf("Expected ErrorNoEditsFound, got: %v", err)
+	}
+
+	if _, err := ur.EditAlertContact(uptimerobot.AlertContact{ID: 42, Value: "x"}); !errors.Is(err, uptimerobot.ErrorAlertContactIDNotExists) {
+		t.Errorf("Expected ErrorAlertContactIDNotExists, got: %v", err)
+	}
+}
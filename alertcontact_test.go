@@ -1,9 +1,9 @@
 package uptimerobot
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 	"testing"
 
 	"github.com/satori/go.uuid"
@@ -100,6 +100,15 @@ func TestNewAlertContactMissingParameters(t *testing.T) {
 	}
 }
 
+func TestEditAlertContactMissingID(t *testing.T) {
+	ur := New(os.Getenv("UR_API_KEY"))
+
+	_, err := ur.EditAlertContact(AlertContact{Value: "foo@example.com"})
+	if err == nil || err.Error() != "Required parameters misisng. Please check the documentation." {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+}
+
 func TestNewAlertContactWrongParameters(t *testing.T) {
 	ur := New(os.Getenv("UR_API_KEY"))
 	ur.FullDebug = false
@@ -115,8 +124,13 @@ func TestNewAlertContactWrongParameters(t *testing.T) {
 		t.Fatalf("Test should have errored.")
 	}
 
-	if !strings.HasPrefix(err.Error(), "Contact not created") {
-		t.Errorf("Got an unexpected error string: %s", err)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an API error, got: %s", err)
+	}
+
+	if apiErr.Method != "newAlertContact" || apiErr.Code == 0 {
+		t.Errorf("Got an unexpected API error: %+v", apiErr)
 	}
 }
 
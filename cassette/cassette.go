@@ -0,0 +1,289 @@
+// Package cassette records the exchanges of the uptimerobot client with the
+// API into fixture files and replays them, so tests can run offline and
+// deterministic.
+//
+// A cassette is an http.RoundTripper used through the HTTPClient of the
+// client. While recording, the requests are sent to the API and the exchanges
+// are written to the file on Save. The API-key and passwords are scrubbed from
+// the parameters and responses before they are stored:
+//
+//	c, err := cassette.Open("testdata/monitors.json", cassette.ModeRecord)
+//	ur := uptimerobot.New(apiKey)
+//	ur.HTTPClient = c.Client()
+//	// ... calls against the API
+//	err = c.Save()
+//
+// When replaying, requests are matched on the API method and their normalized
+// parameters. The cache-busting "v" parameter and the scrubbed values are not
+// compared. Every recorded exchange is used once in the recorded order, so
+// repeated calls of the same method get the successive responses.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Jimdo/uptimerobot-api/internal/redact"
+)
+
+// Mode selects whether a cassette records or replays the exchanges
+type Mode int
+
+// Modes of a cassette
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+// Scrubbed replaces the values of secret parameters and response fields
+const Scrubbed = redact.Redacted
+
+// DefaultIgnoreParams are the parameters not compared when matching requests:
+// the cache buster added by the client
+var DefaultIgnoreParams = []string{"v"}
+
+// Interaction is a single recorded exchange with the API
+type Interaction struct {
+	// the API method (Example: "getMonitors")
+	Method string `json:"method"`
+	// the scrubbed parameters of the request without the ignored ones
+	Params url.Values `json:"params"`
+	// the HTTP status code and the scrubbed body of the response, bodies which
+	// are not JSON are kept as Text
+	StatusCode int             `json:"statusCode"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Text       string          `json:"text,omitempty"`
+}
+
+// Cassette records or replays the exchanges with the API
+type Cassette struct {
+	// Path is the fixture file the interactions are read from or saved to
+	Path string
+	// Mode selects recording or replaying
+	Mode Mode
+	// Transport sends the requests while recording (nil uses
+	// http.DefaultTransport)
+	Transport http.RoundTripper
+	// IgnoreParams are left out when storing and matching requests
+	IgnoreParams []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Open creates a cassette for the given fixture file. In ModeReplay the file
+// is loaded and has to exist, in ModeRecord it is created or replaced on Save.
+func Open(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		Path:         path,
+		Mode:         mode,
+		IgnoreParams: DefaultIgnoreParams,
+	}
+
+	if mode == ModeRecord {
+		return c, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.interactions); err != nil {
+		return nil, fmt.Errorf("Unable to parse cassette %s: %s", path, err)
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// Client returns an HTTP client using the cassette as transport
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Interactions returns the recorded or loaded interactions
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction{}, c.interactions...)
+}
+
+// Unused returns the loaded interactions which have not been replayed yet,
+// tests can check it to ensure all expected calls happened
+func (c *Cassette) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unused := []Interaction{}
+	for i, in := range c.interactions {
+		if !c.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the fixture file
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.Path, append(data, '\n'), 0644)
+}
+
+// RoundTrip records or replays a single request
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, params, secrets, err := c.readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Mode == ModeRecord {
+		return c.record(req, method, params, secrets)
+	}
+	return c.replay(req, method, params)
+}
+
+// readRequest extracts the API method and the scrubbed and normalized
+// parameters of the request. It also returns the secret values to scrub from
+// the response and restores the body of the request for sending it.
+func (c *Cassette) readRequest(req *http.Request) (string, url.Values, []string, error) {
+	params := url.Values{}
+	for k, v := range req.URL.Query() {
+		params[k] = v
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", nil, nil, err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	}
+
+	for _, k := range c.IgnoreParams {
+		params.Del(k)
+	}
+
+	secrets := []string{}
+	for k := range params {
+		if !redact.IsSecret(k) {
+			continue
+		}
+		for _, v := range params[k] {
+			if v != "" {
+				secrets = append(secrets, v)
+			}
+		}
+		// replays match whatever key or password is used
+		params[k] = []string{Scrubbed}
+	}
+
+	return path.Base(req.URL.Path), params, secrets, nil
+}
+
+func (c *Cassette) record(req *http.Request, method string, params url.Values, secrets []string) (*http.Response, error) {
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := Interaction{
+		Method:     method,
+		Params:     params,
+		StatusCode: res.StatusCode,
+	}
+	scrubbed := scrub(body, secrets)
+	if json.Valid(scrubbed) {
+		in.Response = scrubbed
+	} else {
+		in.Text = string(scrubbed)
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, in)
+	c.used = append(c.used, true)
+	c.mu.Unlock()
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+func (c *Cassette) replay(req *http.Request, method string, params url.Values) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	encoded := params.Encode()
+	for i, in := range c.interactions {
+		if c.used[i] || in.Method != method || in.Params.Encode() != encoded {
+			continue
+		}
+		c.used[i] = true
+
+		body := []byte(in.Text)
+		if len(in.Response) > 0 {
+			body = in.Response
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+			StatusCode:    in.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("No interaction recorded in %s for %s with %s", c.Path, method, encoded)
+}
+
+// scrub replaces the values of secret fields and any occurrence of the secret
+// values of the request in a response body. Empty fields are kept so replays
+// return what the API did.
+func scrub(body []byte, secrets []string) []byte {
+	s := redact.Body(body, true)
+	for _, secret := range secrets {
+		s = strings.Replace(s, secret, Scrubbed, -1)
+	}
+	return []byte(s)
+}
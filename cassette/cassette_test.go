@@ -0,0 +1,153 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+
+	path := filepath.Join(t.TempDir(), "cassettes", "flow.json")
+
+	rec, err := Open(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	rec.Transport = srv.Client().HTTPClient.Transport
+
+	ur := srv.Client()
+	ur.HTTPClient = rec.Client()
+	ur.FullDebug = true
+	ur.Logger = discardLogger{}
+
+	monitor := uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP, HTTPUsername: "monitor", HTTPPassword: "s3cr3t"}
+	created, err := ur.NewOrEditMonitor(monitor)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	recorded, err := ur.GetMonitors(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if err := ur.DeleteMonitor(4242); err == nil {
+		t.Fatalf("Expected the deletion of an unknown monitor to fail")
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if strings.Contains(string(data), "u1234-testkey") || strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), `"v"`) {
+		t.Errorf("Expected secrets and cache busters to be scrubbed: %s", data)
+	}
+
+	// Replay without the API and with another key
+	srv.Close()
+
+	c, err := Open(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(c.Unused()) != 3 {
+		t.Fatalf("Expected 3 interactions, got %+v", c.Interactions())
+	}
+
+	ur = uptimerobot.New("u9876-otherkey")
+	ur.BaseURL = "http://replay.invalid"
+	ur.HTTPClient = c.Client()
+	ur.FullDebug = true
+	ur.Logger = discardLogger{}
+
+	replayed, err := ur.NewOrEditMonitor(monitor)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if replayed.ID != created.ID {
+		t.Errorf("Expected ID %d, got %d", created.ID, replayed.ID)
+	}
+
+	mons, err := ur.GetMonitors(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(mons) != len(recorded) || mons[1].FriendlyName != "api" || mons[1].HTTPPassword != Scrubbed || mons[0].HTTPPassword != "" {
+		t.Errorf("Unexpected monitors: %+v", mons)
+	}
+
+	if err := ur.DeleteMonitor(4242); !errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+		t.Errorf("Expected the recorded error, got: %v", err)
+	}
+
+	if len(c.Unused()) != 0 {
+		t.Errorf("Expected all interactions to be used, got %+v", c.Unused())
+	}
+
+	// Every interaction is replayed once
+	if _, err := ur.GetMonitors(nil); err == nil || !strings.Contains(err.Error(), "No interaction recorded") {
+		t.Errorf("Expected an unmatched request to fail, got: %v", err)
+	}
+}
+
+func TestReplayMatching(t *testing.T) {
+	key := map[string][]string{"api_key": {Scrubbed}, "format": {"json"}}
+	c := &Cassette{
+		Path:         "test.json",
+		IgnoreParams: DefaultIgnoreParams,
+		interactions: []Interaction{
+			{Method: "resetMonitor", Params: map[string][]string{"api_key": {Scrubbed}, "format": {"json"}, "id": {"2"}}, StatusCode: 200, Response: []byte(`{"stat":"ok","monitor":{"id":2}}`)},
+			{Method: "resetMonitor", Params: map[string][]string{"api_key": {Scrubbed}, "format": {"json"}, "id": {"1"}}, StatusCode: 200, Response: []byte(`{"stat":"ok","monitor":{"id":1}}`)},
+			{Method: "getAccountDetails", Params: key, StatusCode: 502, Text: "Bad Gateway"},
+			{Method: "getAccountDetails", Params: key, StatusCode: 200, Response: []byte(`{"stat":"ok","account":{"email":"ops@example.com"}}`)},
+		},
+		used: make([]bool, 4),
+	}
+
+	ur := uptimerobot.New("u1234-testkey")
+	ur.HTTPClient = c.Client()
+	ur.RetryPolicy = &uptimerobot.RetryPolicy{MaxAttempts: 2}
+	ur.FullDebug = true
+	ur.Logger = discardLogger{}
+
+	// Requests are matched on their parameters, not their order
+	if err := ur.ResetMonitor(1); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if err := ur.ResetMonitor(2); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	// The first matching interaction fails with 502, the retry gets the next
+	// one
+	account, err := ur.GetAccountDetails()
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if account.Email != "ops@example.com" {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	if len(c.Unused()) != 0 {
+		t.Errorf("Expected all interactions to be used, got %+v", c.Unused())
+	}
+}
+
+type discardLogger struct{}
+
+func (discardLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {}
+func (discardLogger) WarnContext(ctx context.Context, msg string, args ...interface{})  {}
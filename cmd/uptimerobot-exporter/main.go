@@ -0,0 +1,129 @@
+// Command uptimerobot-exporter serves the status, uptime ratios and response
+// times of the UptimeRobot monitors and the limits of the account as
+// Prometheus metrics.
+//
+// Usage:
+//
+//	uptimerobot-exporter [-listen :9705] [-interval 5m] [-uptime-ratios 7,30] [-rate-limit 10]
+//
+// The API is polled every interval and scrapes are answered from the data of
+// the last poll, the requests to the API are limited to rate-limit per
+// minute. The API-key is read from the UPTIMEROBOT_API_KEY environment
+// variable. The metrics are served on /metrics (see the exporter package for
+// the list).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/exporter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stderr, os.Getenv))
+}
+
+func run(ctx context.Context, args []string, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("uptimerobot-exporter", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	listen := fs.String("listen", ":9705", "address to serve the metrics on")
+	interval := fs.Duration("interval", 5*time.Minute, "time between two polls of the API, at least 1m")
+	ratios := fs.String("uptime-ratios", "", "comma separated periods in days to expose uptime ratios for (Example: 7,30)")
+	rateLimit := fs.Int("rate-limit", 10, "max number of requests sent to the API per minute")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	periods, err := parseRatios(*ratios)
+	if err != nil || *rateLimit < 1 || fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "Invalid arguments, see -help")
+		return 2
+	}
+
+	apikey := getenv("UPTIMEROBOT_API_KEY")
+	if apikey == "" {
+		fmt.Fprintln(stderr, "No API-key given, set UPTIMEROBOT_API_KEY")
+		return 1
+	}
+
+	ur := uptimerobot.New(apikey)
+	ur.RateLimiter = uptimerobot.NewRateLimiter(*rateLimit, time.Minute)
+
+	c := exporter.NewCollector(ur)
+	c.Interval = *interval
+	c.CustomUptimeRatios = periods
+
+	srv := &http.Server{Addr: *listen, Handler: newHandler(c)}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go c.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newHandler serves the metrics of the collector on /metrics
+func newHandler(c prometheus.Collector) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><h1>UptimeRobot exporter</h1><a href="/metrics">Metrics</a></body></html>`)
+	})
+	return mux
+}
+
+// parseRatios parses the comma separated list of periods in days. Repeated
+// periods are rejected as they would be exposed as duplicate series, which
+// fails the whole scrape.
+func parseRatios(s string) ([]int, error) {
+	periods := []int{}
+	if s == "" {
+		return periods, nil
+	}
+
+	seen := map[int]bool{}
+	for _, p := range strings.Split(s, ",") {
+		days, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || days < 1 {
+			return nil, fmt.Errorf("invalid period %q", p)
+		}
+		if seen[days] {
+			return nil, fmt.Errorf("period %d given more than once", days)
+		}
+		seen[days] = true
+		periods = append(periods, days)
+	}
+	return periods, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Jimdo/uptimerobot-api/exporter"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestRunArguments(t *testing.T) {
+	for _, tc := range []struct {
+		args   []string
+		env    map[string]string
+		code   int
+		stderr string
+	}{
+		{args: []string{"-uptime-ratios", "7,x"}, code: 2, stderr: "Invalid arguments"},
+		{args: []string{"-rate-limit", "0"}, code: 2, stderr: "Invalid arguments"},
+		{args: []string{"-unknown"}, code: 2, stderr: "flag provided but not defined"},
+		{args: []string{}, code: 1, stderr: "No API-key given"},
+		{args: []string{"-listen", "invalid:address:"}, env: map[string]string{"UPTIMEROBOT_API_KEY": "u1234-testkey"}, code: 1, stderr: "Error:"},
+	} {
+		var stderr bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		code := run(ctx, tc.args, &stderr, func(k string) string { return tc.env[k] })
+		cancel()
+
+		if code != tc.code || !strings.Contains(stderr.String(), tc.stderr) {
+			t.Errorf("%v: Expected code %d with %q, got %d: %s", tc.args, tc.code, tc.stderr, code, stderr.String())
+		}
+	}
+}
+
+func TestParseRatios(t *testing.T) {
+	periods, err := parseRatios("7, 30")
+	if err != nil || !reflect.DeepEqual(periods, []int{7, 30}) {
+		t.Errorf("Unexpected periods %v (%v)", periods, err)
+	}
+
+	if periods, err := parseRatios(""); err != nil || len(periods) != 0 {
+		t.Errorf("Unexpected periods %v (%v)", periods, err)
+	}
+
+	if _, err := parseRatios("0"); err == nil {
+		t.Errorf("Expected an error for a period of 0 days")
+	}
+
+	if _, err := parseRatios("7,30,7"); err == nil {
+		t.Errorf("Expected an error for a repeated period")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	c := exporter.NewCollector(srv.Client())
+	if err := c.Poll(context.Background()); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	h := httptest.NewServer(newHandler(c))
+	defer h.Close()
+
+	res, err := h.Client().Get(h.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != 200 || !strings.Contains(string(body), "uptimerobot_poll_success 1") {
+		t.Errorf("Unexpected response %d: %s", res.StatusCode, body)
+	}
+
+	if res, err := h.Client().Get(h.URL + "/other"); err != nil || res.StatusCode != 404 {
+		t.Errorf("Expected 404 for unknown paths, got %v (%v)", res, err)
+	}
+}
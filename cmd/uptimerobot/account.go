@@ -0,0 +1,26 @@
+package main
+
+import "strconv"
+
+func showAccount(a *app, args []string) error {
+	fs := newFlagSet(a, "account show")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	ad, err := a.ur.GetAccountDetails()
+	if err != nil {
+		return err
+	}
+
+	return a.write(ad, table{
+		header: []string{"MONITOR LIMIT", "MIN INTERVAL", "UP", "DOWN", "PAUSED"},
+		rows: [][]string{{
+			strconv.Itoa(ad.MonitorLimit),
+			strconv.Itoa(ad.MonitorInterval) + "m",
+			strconv.Itoa(ad.UpMonitors),
+			strconv.Itoa(ad.DownMonitors),
+			strconv.Itoa(ad.PausedMonitors),
+		}},
+	})
+}
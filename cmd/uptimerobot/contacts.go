@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func listContacts(a *app, args []string) error {
+	fs := newFlagSet(a, "contacts list")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	contacts, err := a.ur.GetAlertContacts(nil)
+	if err != nil {
+		return err
+	}
+	return a.writeContacts(contacts)
+}
+
+func createContact(a *app, args []string) error {
+	fs := newFlagSet(a, "contacts create")
+	typ := fs.String("type", "", "alert contact type (email, sms, webhook, slack, ...)")
+	value := fs.String("value", "", "e-mail address, phone number, URL, ... to alert")
+	friendlyName := fs.String("name", "", "friendly name of the alert contact")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 || *typ == "" || *value == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	t, err := parseName(contactTypeNames, *typ)
+	if err != nil {
+		return err
+	}
+
+	created, err := a.ur.NewAlertContact(uptimerobot.AlertContact{
+		Type:         uptimerobot.AlertContactType(t),
+		Value:        *value,
+		FriendlyName: *friendlyName,
+	})
+	if err != nil {
+		return err
+	}
+	return a.writeContacts([]uptimerobot.AlertContact{*created})
+}
+
+func editContact(a *app, args []string) error {
+	fs := newFlagSet(a, "contacts edit")
+	value := fs.String("value", "", "e-mail address, phone number, URL, ... to alert")
+	friendlyName := fs.String("name", "", "friendly name of the alert contact")
+	status := fs.String("status", "", "pause or activate the alert contact (paused, active)")
+	if len(args) == 0 {
+		fmt.Fprintln(a.err, "Usage: uptimerobot contacts edit <id> [flags]")
+		return errUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid alert contact ID %q", args[0])
+	}
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	in := uptimerobot.AlertContact{ID: id, Value: *value, FriendlyName: *friendlyName}
+	if *status != "" {
+		s, err := parseName(contactStatusNames, *status)
+		if err != nil {
+			return err
+		}
+		in.Status = uptimerobot.AlertContactStatus(s)
+	}
+
+	edited, err := a.ur.EditAlertContact(in)
+	if err != nil {
+		return err
+	}
+	return a.writeContacts([]uptimerobot.AlertContact{*edited})
+}
+
+func deleteContacts(a *app, args []string) error {
+	return forEachID(a, "contacts delete", args, a.ur.DeleteAlertContact)
+}
+
+func (a *app) writeContacts(contacts []uptimerobot.AlertContact) error {
+	t := table{header: []string{"ID", "NAME", "TYPE", "STATUS", "VALUE"}}
+	for _, c := range contacts {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(c.ID),
+			c.FriendlyName,
+			name(contactTypeNames, int(c.Type)),
+			name(contactStatusNames, int(c.Status)),
+			c.Value,
+		})
+	}
+	return a.write(contacts, t)
+}
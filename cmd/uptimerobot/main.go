@@ -0,0 +1,218 @@
+// Command uptimerobot manages UptimeRobot monitors, alert contacts,
+// maintenance windows, public status pages and account information from the
+// command line.
+//
+// Usage:
+//
+//	uptimerobot [-config file] [-o table|json|csv] <resource> <command> [flags] [args]
+//
+//	uptimerobot monitors list [-types http,keyword] [-statuses up,down] [-search text]
+//	uptimerobot monitors get <id>...
+//	uptimerobot monitors create -name <name> -url <url> -type <type> [flags]
+//	uptimerobot monitors edit <id> [flags]
+//	uptimerobot monitors delete <id>...
+//	uptimerobot monitors reset <id>...
+//	uptimerobot monitors pause <id>...
+//	uptimerobot monitors resume <id>...
+//	uptimerobot contacts list
+//	uptimerobot contacts create -type <type> -value <value> [-name <name>]
+//	uptimerobot contacts edit <id> [-value <value>] [-name <name>] [-status paused|active]
+//	uptimerobot contacts delete <id>...
+//	uptimerobot mwindows list
+//	uptimerobot mwindows create -name <name> -type <type> -start <time> -duration <duration> [-days 1,3] [-monitors <id>,...]
+//	uptimerobot mwindows delete <id>...
+//	uptimerobot psps list
+//	uptimerobot psps create -name <name> [-monitors <id>,...] [-domain <domain>] [-password <password>] [-sort <order>]
+//	uptimerobot psps sync <id> [-types http,keyword] [-statuses up,down] [-search text]
+//	uptimerobot psps delete <id>...
+//	uptimerobot account show
+//
+// The API-key is read from the UPTIMEROBOT_API_KEY environment variable or the
+// api_key setting of the config file (default: uptimerobot/config in the user
+// config directory, for example ~/.config/uptimerobot/config):
+//
+//	# uptimerobot config
+//	api_key = u1234-0123456789abcdef
+//	base_url = https://api.uptimerobot.com/v2
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+type app struct {
+	ur     *uptimerobot.UptimeRobot
+	out    io.Writer
+	err    io.Writer
+	format string
+}
+
+type command func(a *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"monitors": {
+		"list":   listMonitors,
+		"get":    getMonitors,
+		"create": createMonitor,
+		"edit":   editMonitor,
+		"delete": deleteMonitors,
+		"reset":  resetMonitors,
+		"pause":  pauseMonitors,
+		"resume": resumeMonitors,
+	},
+	"contacts": {
+		"list":   listContacts,
+		"create": createContact,
+		"edit":   editContact,
+		"delete": deleteContacts,
+	},
+	"mwindows": {
+		"list":   listMaintenanceWindows,
+		"create": createMaintenanceWindow,
+		"delete": deleteMaintenanceWindows,
+	},
+	"psps": {
+		"list":   listPublicStatusPages,
+		"create": createPublicStatusPage,
+		"sync":   syncPublicStatusPage,
+		"delete": deletePublicStatusPages,
+	},
+	"account": {
+		"show": showAccount,
+	},
+}
+
+// errUsage is returned by commands invoked with invalid arguments, the flag
+// package already printed the details
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+func run(args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("uptimerobot", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFile := fs.String("config", "", "path of the config file")
+	format := fs.String("o", "table", "output format: table, json or csv")
+	fs.Usage = func() { usage(stderr) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() < 2 || commands[fs.Arg(0)] == nil || commands[fs.Arg(0)][fs.Arg(1)] == nil {
+		usage(stderr)
+		return 2
+	}
+
+	if *format != "table" && *format != "json" && *format != "csv" {
+		fmt.Fprintf(stderr, "Unknown output format %q\n", *format)
+		return 2
+	}
+
+	cfg, err := loadConfig(*configFile, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "Unable to read config: %s\n", err)
+		return 1
+	}
+
+	if cfg["api_key"] == "" {
+		fmt.Fprintln(stderr, "No API-key given, set UPTIMEROBOT_API_KEY or api_key in the config file")
+		return 1
+	}
+
+	a := &app{
+		ur:     uptimerobot.New(cfg["api_key"]),
+		out:    stdout,
+		err:    stderr,
+		format: *format,
+	}
+	if cfg["base_url"] != "" {
+		a.ur.BaseURL = cfg["base_url"]
+	}
+
+	cmd := commands[fs.Arg(0)][fs.Arg(1)]
+	if err := cmd(a, fs.Args()[2:]); err != nil {
+		if err != errUsage {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: uptimerobot [-config file] [-o table|json|csv] <resource> <command> [flags] [args]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, resource := range []string{"monitors", "contacts", "mwindows", "psps", "account"} {
+		names := []string{}
+		for name := range commands[resource] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "  %-9s %s\n", resource, strings.Join(names, ", "))
+	}
+}
+
+// loadConfig reads the key = value pairs of the config file and applies the
+// environment on top of it
+func loadConfig(path string, getenv func(string) string) (map[string]string, error) {
+	cfg := map[string]string{}
+
+	explicit := path != "" || getenv("UPTIMEROBOT_CONFIG") != ""
+	if path == "" {
+		path = getenv("UPTIMEROBOT_CONFIG")
+	}
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "uptimerobot", "config")
+		}
+	}
+
+	if path != "" {
+		f, err := os.Open(path)
+		switch {
+		case err == nil:
+			defer f.Close()
+			if err := parseConfig(f, cfg); err != nil {
+				return nil, fmt.Errorf("%s: %s", path, err)
+			}
+		case explicit || !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
+	if v := getenv("UPTIMEROBOT_API_KEY"); v != "" {
+		cfg["api_key"] = v
+	}
+	return cfg, nil
+}
+
+func parseConfig(r io.Reader, cfg map[string]string) error {
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(text, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("line %d: expected key = value", line)
+		}
+		cfg[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return s.Err()
+}
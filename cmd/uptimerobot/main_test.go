@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func runCLI(t *testing.T, srv *uptimerobottest.Server, args ...string) (string, int) {
+	out := &bytes.Buffer{}
+	env := map[string]string{
+		"UPTIMEROBOT_API_KEY": srv.APIKey,
+		"UPTIMEROBOT_CONFIG":  "",
+	}
+
+	cfg := t.TempDir() + "/config"
+	if err := os.WriteFile(cfg, []byte("base_url = "+srv.URL+"\n"), 0600); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	code := run(append([]string{"-config", cfg}, args...), out, out, func(k string) string { return env[k] })
+	return out.String(), code
+}
+
+func TestMonitorCommands(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	out, code := runCLI(t, srv, "monitors", "create", "-name", "web", "-url", "https://www.example.com/", "-type", "http", "-interval", "5m")
+	if code != 0 {
+		t.Fatalf("Create failed (%d): %s", code, out)
+	}
+
+	srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "ping", URL: "gateway.example.com", Type: uptimerobot.MonitorTypePing, Status: uptimerobot.MonitorStatusUp})
+
+	out, code = runCLI(t, srv, "-o", "csv", "monitors", "list", "-types", "ping", "-statuses", "up")
+	if code != 0 {
+		t.Fatalf("List failed (%d): %s", code, out)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.Contains(lines[1], ",ping,ping,up,") {
+		t.Errorf("Unexpected CSV output:\n%s", out)
+	}
+
+	id := srv.Monitors()[0].ID
+	out, code = runCLI(t, srv, "monitors", "edit", strconv.Itoa(id), "-name", "renamed")
+	if code != 0 {
+		t.Fatalf("Edit failed (%d): %s", code, out)
+	}
+	if m := srv.Monitors()[0]; m.FriendlyName != "renamed" || m.Interval != 300 {
+		t.Errorf("Monitor was not edited as expected: %+v", m)
+	}
+
+	out, code = runCLI(t, srv, "monitors", "pause", strconv.Itoa(id))
+	if m := srv.Monitors()[0]; code != 0 || m.Status != uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor was not paused (%d): %s", code, out)
+	}
+
+	out, code = runCLI(t, srv, "-o", "json", "monitors", "get", strconv.Itoa(id))
+	monitors := []uptimerobot.Monitor{}
+	if err := json.Unmarshal([]byte(out), &monitors); code != 0 || err != nil || len(monitors) != 1 || monitors[0].ID != id {
+		t.Errorf("Unexpected JSON output (%d, %v):\n%s", code, err, out)
+	}
+
+	out, code = runCLI(t, srv, "monitors", "delete", strconv.Itoa(id), "42")
+	if code != 1 || !strings.Contains(out, "42: ") {
+		t.Errorf("Expected the second delete to fail (%d):\n%s", code, out)
+	}
+	if len(srv.Monitors()) != 1 {
+		t.Errorf("Expected the first monitor to be deleted")
+	}
+
+	secured := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "admin", URL: "http://admin.example.com/", Type: uptimerobot.MonitorTypeHTTP, HTTPUsername: "admin", HTTPPassword: "s3cr3t"})
+	out, code = runCLI(t, srv, "-o", "json", "monitors", "get", strconv.Itoa(secured.ID))
+	if code != 0 || strings.Contains(out, "s3cr3t") || !strings.Contains(out, `"httppassword": "REDACTED"`) {
+		t.Errorf("Expected the password to be masked (%d):\n%s", code, out)
+	}
+}
+
+func TestOtherCommands(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	out, code := runCLI(t, srv, "contacts", "create", "-type", "email", "-value", "ops@example.com", "-name", "ops")
+	if code != 0 || !strings.Contains(out, "ops@example.com") {
+		t.Fatalf("Create failed (%d): %s", code, out)
+	}
+
+	id := srv.AlertContacts()[0].ID
+	out, code = runCLI(t, srv, "contacts", "edit", strconv.Itoa(id), "-value", "oncall@example.com", "-status", "paused")
+	if code != 0 || !strings.Contains(out, "oncall@example.com") {
+		t.Fatalf("Edit failed (%d): %s", code, out)
+	}
+
+	out, code = runCLI(t, srv, "contacts", "list")
+	if code != 0 || !strings.Contains(out, "email") || !strings.Contains(out, "paused") {
+		t.Errorf("Unexpected list output (%d):\n%s", code, out)
+	}
+
+	out, code = runCLI(t, srv, "mwindows", "create", "-name", "deploys", "-type", "weekly", "-days", "2,4", "-start", "18:30", "-duration", "45m")
+	if code != 0 || !strings.Contains(out, "2,4") || !strings.Contains(out, "45m0s") {
+		t.Errorf("Unexpected mwindows output (%d):\n%s", code, out)
+	}
+
+	srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "https://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	out, code = runCLI(t, srv, "psps", "create", "-name", "status", "-sort", "down-up")
+	if code != 0 || !strings.Contains(out, "all") || !strings.Contains(out, "down-up") {
+		t.Errorf("Unexpected psps output (%d):\n%s", code, out)
+	}
+
+	psp := srv.PublicStatusPages()[0]
+	out, code = runCLI(t, srv, "psps", "sync", strconv.Itoa(psp.ID), "-types", "http")
+	if code != 0 || len(srv.PublicStatusPages()[0].Monitors) != 1 {
+		t.Errorf("Status page was not synced (%d):\n%s", code, out)
+	}
+
+	out, code = runCLI(t, srv, "account", "show")
+	if code != 0 || !strings.Contains(out, "MONITOR LIMIT") {
+		t.Errorf("Unexpected account output (%d):\n%s", code, out)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	out := &bytes.Buffer{}
+	if code := run([]string{"monitors", "fly"}, out, out, func(string) string { return "" }); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+
+	if !strings.Contains(out.String(), "Usage: uptimerobot") {
+		t.Errorf("Expected usage output, got:\n%s", out)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func newFlagSet(a *app, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.err)
+	return fs
+}
+
+func listMonitors(a *app, args []string) error {
+	fs := newFlagSet(a, "monitors list")
+	types := fs.String("types", "", "comma separated monitor types to list (http, keyword, ping, port)")
+	statuses := fs.String("statuses", "", "comma separated monitor statuses to list (paused, not-checked, up, seems-down, down)")
+	search := fs.String("search", "", "only list monitors with this text in their URL or friendly name")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	in, err := monitorFilter(*types, *statuses, *search)
+	if err != nil {
+		return err
+	}
+
+	monitors, err := a.ur.GetMonitors(in)
+	if err != nil {
+		return err
+	}
+	return a.writeMonitors(monitors)
+}
+
+// monitorFilter builds the input of GetMonitors from the comma separated type
+// and status names given on the command line
+func monitorFilter(types, statuses, search string) (*uptimerobot.GetMonitorsInput, error) {
+	in := &uptimerobot.GetMonitorsInput{Search: search}
+	for _, t := range splitList(types) {
+		v, err := parseName(monitorTypeNames, t)
+		if err != nil {
+			return nil, err
+		}
+		in.Types = append(in.Types, uptimerobot.MonitorType(v))
+	}
+	for _, s := range splitList(statuses) {
+		v, err := parseName(monitorStatusNames, s)
+		if err != nil {
+			return nil, err
+		}
+		in.Statuses = append(in.Statuses, uptimerobot.MonitorStatus(v))
+	}
+	return in, nil
+}
+
+func getMonitors(a *app, args []string) error {
+	ids, err := parseIDs(args)
+	if err != nil || len(ids) == 0 {
+		fmt.Fprintln(a.err, "Usage: uptimerobot monitors get <id>...")
+		return errUsage
+	}
+
+	monitors, err := a.ur.GetMonitors(&uptimerobot.GetMonitorsInput{
+		Monitors:                 ids,
+		ShowMonitorAlertContacts: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(monitors) != len(ids) {
+		return fmt.Errorf("found %d of %d requested monitors", len(monitors), len(ids))
+	}
+	return a.writeMonitors(monitors)
+}
+
+// monitorFlags registers the flags describing a monitor and returns a function
+// applying the flags which were given to the monitor
+func monitorFlags(fs *flag.FlagSet) func(m *uptimerobot.Monitor) error {
+	name := fs.String("name", "", "friendly name of the monitor")
+	url := fs.String("url", "", "URL, IP or host name to monitor")
+	typ := fs.String("type", "", "monitor type (http, keyword, ping, port)")
+	subtype := fs.String("subtype", "", "port monitor subtype (http, https, ftp, smtp, pop3, imap, custom)")
+	port := fs.Int("port", 0, "port for subtype custom")
+	keywordType := fs.String("keyword-type", "", "keyword monitor type (exists, not-exists)")
+	keywordValue := fs.String("keyword-value", "", "keyword to search for")
+	interval := fs.Duration("interval", 0, "check interval (Example: 5m)")
+	httpUsername := fs.String("http-username", "", "username for HTTP basic auth")
+	httpPassword := fs.String("http-password", "", "password for HTTP basic auth")
+	contacts := fs.String("contacts", "", "comma separated alert contacts as id[:threshold[:recurrence]]")
+
+	return func(m *uptimerobot.Monitor) error {
+		var err error
+		fs.Visit(func(f *flag.Flag) {
+			if err != nil {
+				return
+			}
+
+			var v int
+			switch f.Name {
+			case "name":
+				m.FriendlyName = *name
+			case "url":
+				m.URL = *url
+			case "type":
+				v, err = parseName(monitorTypeNames, *typ)
+				m.Type = uptimerobot.MonitorType(v)
+			case "subtype":
+				v, err = parseName(monitorSubtypeNames, *subtype)
+				m.Subtype = uptimerobot.MonitorSubtype(v)
+			case "port":
+				m.Port = *port
+			case "keyword-type":
+				v, err = parseName(keywordTypeNames, *keywordType)
+				m.KeywordType = uptimerobot.MonitorKeywordType(v)
+			case "keyword-value":
+				m.KeywordValue = *keywordValue
+			case "interval":
+				m.Interval = int(interval.Seconds())
+			case "http-username":
+				m.HTTPUsername = *httpUsername
+			case "http-password":
+				m.HTTPPassword = *httpPassword
+			case "contacts":
+				m.AlertContacts, err = parseContactList(*contacts)
+			}
+		})
+		return err
+	}
+}
+
+func createMonitor(a *app, args []string) error {
+	fs := newFlagSet(a, "monitors create")
+	apply := monitorFlags(fs)
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	m := uptimerobot.Monitor{}
+	if err := apply(&m); err != nil {
+		return err
+	}
+
+	created, err := a.ur.NewOrEditMonitor(m)
+	if err != nil {
+		return err
+	}
+	return a.writeMonitors([]uptimerobot.Monitor{*created})
+}
+
+func editMonitor(a *app, args []string) error {
+	fs := newFlagSet(a, "monitors edit")
+	apply := monitorFlags(fs)
+	if len(args) == 0 {
+		fmt.Fprintln(a.err, "Usage: uptimerobot monitors edit <id> [flags]")
+		return errUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid monitor ID %q", args[0])
+	}
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	monitors, err := a.ur.GetMonitors(&uptimerobot.GetMonitorsInput{
+		Monitors:                 []int{id},
+		ShowMonitorAlertContacts: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(monitors) != 1 {
+		return fmt.Errorf("monitor %d not found", id)
+	}
+
+	m := monitors[0]
+	if err := apply(&m); err != nil {
+		return err
+	}
+
+	edited, err := a.ur.NewOrEditMonitor(m)
+	if err != nil {
+		return err
+	}
+	return a.writeMonitors([]uptimerobot.Monitor{*edited})
+}
+
+func deleteMonitors(a *app, args []string) error {
+	return forEachID(a, "monitors delete", args, a.ur.DeleteMonitor)
+}
+
+func resetMonitors(a *app, args []string) error {
+	return forEachID(a, "monitors reset", args, a.ur.ResetMonitor)
+}
+
+func pauseMonitors(a *app, args []string) error {
+	return forEachID(a, "monitors pause", args, func(id int) error {
+		_, err := a.ur.PauseMonitor(id)
+		return err
+	})
+}
+
+func resumeMonitors(a *app, args []string) error {
+	return forEachID(a, "monitors resume", args, func(id int) error {
+		_, err := a.ur.ResumeMonitor(id)
+		return err
+	})
+}
+
+func (a *app) writeMonitors(monitors []uptimerobot.Monitor) error {
+	// The output is often shared, so the passwords are masked like the
+	// String method of the monitors does
+	masked := make([]uptimerobot.Monitor, len(monitors))
+	for i, m := range monitors {
+		if m.HTTPPassword != "" {
+			m.HTTPPassword = "REDACTED"
+		}
+		masked[i] = m
+	}
+
+	t := table{header: []string{"ID", "NAME", "TYPE", "STATUS", "INTERVAL", "UPTIME", "URL"}}
+	for _, m := range masked {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(m.ID),
+			m.FriendlyName,
+			name(monitorTypeNames, int(m.Type)),
+			name(monitorStatusNames, int(m.Status)),
+			(time.Duration(m.Interval) * time.Second).String(),
+			strconv.FormatFloat(m.AlltimeUptimeRatio, 'f', 2, 64),
+			m.URL,
+		})
+	}
+	return a.write(masked, t)
+}
+
+// forEachID calls fn for every ID given as argument and prints the IDs which
+// were processed successfully
+func forEachID(a *app, cmd string, args []string, fn func(id int) error) error {
+	ids, err := parseIDs(args)
+	if err != nil || len(ids) == 0 {
+		fmt.Fprintf(a.err, "Usage: uptimerobot %s <id>...\n", cmd)
+		return errUsage
+	}
+
+	done := []int{}
+	t := table{header: []string{"ID"}}
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			a.write(done, t)
+			return fmt.Errorf("%d: %s", id, err)
+		}
+		done = append(done, id)
+		t.rows = append(t.rows, []string{strconv.Itoa(id)})
+	}
+	return a.write(done, t)
+}
+
+func parseIDs(args []string) ([]int, error) {
+	ids := []int{}
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseContactList parses alert contact assignments given as
+// id[:threshold[:recurrence]],...
+func parseContactList(in string) ([]uptimerobot.AlertContact, error) {
+	contacts := []uptimerobot.AlertContact{}
+	for _, spec := range splitList(in) {
+		parts := strings.Split(spec, ":")
+		nums := make([]int, 3)
+		for i, p := range parts {
+			n, err := strconv.Atoi(p)
+			if err != nil || i >= len(nums) {
+				return nil, fmt.Errorf("invalid alert contact %q, expected id[:threshold[:recurrence]]", spec)
+			}
+			nums[i] = n
+		}
+		contacts = append(contacts, uptimerobot.AlertContact{ID: nums[0], Threshold: nums[1], Recurrence: nums[2]})
+	}
+	return contacts, nil
+}
+
+func splitList(in string) []string {
+	out := []string{}
+	for _, s := range strings.Split(in, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func listMaintenanceWindows(a *app, args []string) error {
+	fs := newFlagSet(a, "mwindows list")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	windows, err := a.ur.GetMaintenanceWindows(nil)
+	if err != nil {
+		return err
+	}
+	return a.writeMaintenanceWindows(windows)
+}
+
+func createMaintenanceWindow(a *app, args []string) error {
+	fs := newFlagSet(a, "mwindows create")
+	friendlyName := fs.String("name", "", "friendly name of the maintenance window")
+	typ := fs.String("type", "", "how often the window recurs (once, daily, weekly, monthly)")
+	days := fs.String("days", "", "comma separated weekdays (1 = Monday) or days of the month")
+	start := fs.String("start", "", "start time, \"2006-01-02 15:04\" (UTC) for one-time windows, \"15:04\" otherwise")
+	duration := fs.Duration("duration", 0, "length of the window (Example: 1h30m)")
+	monitors := fs.String("monitors", "", "comma separated IDs of the monitors to attach")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 || *friendlyName == "" || *typ == "" || *start == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	t, err := parseName(mwindowTypeNames, *typ)
+	if err != nil {
+		return err
+	}
+
+	w := uptimerobot.MaintenanceWindow{
+		Type:         uptimerobot.MaintenanceWindowType(t),
+		FriendlyName: *friendlyName,
+		Duration:     *duration,
+	}
+
+	format := "15:04"
+	if w.Type == uptimerobot.MaintenanceWindowTypeOnce {
+		format = "2006-01-02 15:04"
+	}
+	if w.Start, err = time.Parse(format, *start); err != nil {
+		return fmt.Errorf("invalid start time %q, expected %q", *start, format)
+	}
+
+	if w.Days, err = parseIDs(splitList(*days)); err != nil {
+		return err
+	}
+	if w.Monitors, err = parseIDs(splitList(*monitors)); err != nil {
+		return err
+	}
+
+	created, err := a.ur.NewOrEditMaintenanceWindow(w)
+	if err != nil {
+		return err
+	}
+	return a.writeMaintenanceWindows([]uptimerobot.MaintenanceWindow{*created})
+}
+
+func deleteMaintenanceWindows(a *app, args []string) error {
+	return forEachID(a, "mwindows delete", args, a.ur.DeleteMaintenanceWindow)
+}
+
+func (a *app) writeMaintenanceWindows(windows []uptimerobot.MaintenanceWindow) error {
+	t := table{header: []string{"ID", "NAME", "TYPE", "DAYS", "START", "DURATION", "STATUS", "MONITORS"}}
+	for _, w := range windows {
+		start := w.Start.Format("15:04")
+		if w.Type == uptimerobot.MaintenanceWindowTypeOnce {
+			start = w.Start.Format("2006-01-02 15:04")
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(w.ID),
+			w.FriendlyName,
+			name(mwindowTypeNames, int(w.Type)),
+			joinInts(w.Days),
+			start,
+			w.Duration.String(),
+			name(mwindowStatusNames, int(w.Status)),
+			joinInts(w.Monitors),
+		})
+	}
+	return a.write(windows, t)
+}
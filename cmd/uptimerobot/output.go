@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+var (
+	monitorTypeNames = map[int]string{
+		int(uptimerobot.MonitorTypeHTTP):    "http",
+		int(uptimerobot.MonitorTypeKeyword): "keyword",
+		int(uptimerobot.MonitorTypePing):    "ping",
+		int(uptimerobot.MonitorTypePort):    "port",
+	}
+	monitorSubtypeNames = map[int]string{
+		int(uptimerobot.MonitorSubtypeHTTP):       "http",
+		int(uptimerobot.MonitorSubtypeHTTPS):      "https",
+		int(uptimerobot.MonitorSubtypeFTP):        "ftp",
+		int(uptimerobot.MonitorSubtypeSMTP):       "smtp",
+		int(uptimerobot.MonitorSubtypePOP3):       "pop3",
+		int(uptimerobot.MonitorSubtypeIMAP):       "imap",
+		int(uptimerobot.MonitorSubtypeCustomPort): "custom",
+	}
+	monitorStatusNames = map[int]string{
+		int(uptimerobot.MonitorStatusPaused):        "paused",
+		int(uptimerobot.MonitorStatusNotCheckedYet): "not-checked",
+		int(uptimerobot.MonitorStatusUp):            "up",
+		int(uptimerobot.MonitorStatusSeemsDown):     "seems-down",
+		int(uptimerobot.MonitorStatusDown):          "down",
+	}
+	keywordTypeNames = map[int]string{
+		int(uptimerobot.MonitorKeywordTypeExists):    "exists",
+		int(uptimerobot.MonitorKeywordTypeNotExists): "not-exists",
+	}
+	contactTypeNames = map[int]string{
+		int(uptimerobot.AlertContactTypeSMS):        "sms",
+		int(uptimerobot.AlertContactTypeEMail):      "email",
+		int(uptimerobot.AlertContactTypeTwitterDM):  "twitter-dm",
+		int(uptimerobot.AlertContactTypeBoxcar):     "boxcar",
+		int(uptimerobot.AlertContactTypeWebHook):    "webhook",
+		int(uptimerobot.AlertContactTypePushBullet): "pushbullet",
+		int(uptimerobot.AlertContactTypeZapier):     "zapier",
+		int(uptimerobot.AlertContactTypePushover):   "pushover",
+		int(uptimerobot.AlertContactTypeHipChat):    "hipchat",
+		int(uptimerobot.AlertContactTypeSlack):      "slack",
+	}
+	pspSortNames = map[int]string{
+		int(uptimerobot.PublicStatusPageSortFriendlyNameAZ): "name-az",
+		int(uptimerobot.PublicStatusPageSortFriendlyNameZA): "name-za",
+		int(uptimerobot.PublicStatusPageSortStatusUpDown):   "up-down",
+		int(uptimerobot.PublicStatusPageSortStatusDownUp):   "down-up",
+	}
+	pspStatusNames = map[int]string{
+		int(uptimerobot.PublicStatusPageStatusPaused): "paused",
+		int(uptimerobot.PublicStatusPageStatusActive): "active",
+	}
+	mwindowTypeNames = map[int]string{
+		int(uptimerobot.MaintenanceWindowTypeOnce):    "once",
+		int(uptimerobot.MaintenanceWindowTypeDaily):   "daily",
+		int(uptimerobot.MaintenanceWindowTypeWeekly):  "weekly",
+		int(uptimerobot.MaintenanceWindowTypeMonthly): "monthly",
+	}
+	mwindowStatusNames = map[int]string{
+		int(uptimerobot.MaintenanceWindowStatusPaused): "paused",
+		int(uptimerobot.MaintenanceWindowStatusActive): "active",
+	}
+	contactStatusNames = map[int]string{
+		int(uptimerobot.AlertContactStatusNotActivated): "not-activated",
+		int(uptimerobot.AlertContactStatusPaused):       "paused",
+		int(uptimerobot.AlertContactStatusActive):       "active",
+	}
+)
+
+// name returns the name of an enumeration value or its number if unknown
+func name(names map[int]string, value int) string {
+	if s, ok := names[value]; ok {
+		return s
+	}
+	return strconv.Itoa(value)
+}
+
+// parseName is the inverse of name, accepting numbers as well
+func parseName(names map[int]string, in string) (int, error) {
+	valid := []string{}
+	for v, n := range names {
+		if n == strings.ToLower(in) || strconv.Itoa(v) == in {
+			return v, nil
+		}
+		valid = append(valid, n)
+	}
+	sort.Strings(valid)
+	return 0, fmt.Errorf("unknown value %q, expected one of %s", in, strings.Join(valid, ", "))
+}
+
+func joinInts(in []int) string {
+	parts := make([]string, 0, len(in))
+	for _, i := range in {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return strings.Join(parts, ",")
+}
+
+// table is the tabular representation of a command result used for the table
+// and csv output formats
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// write prints the result of a command in the selected format. JSON output
+// encodes data as it was returned by the API client.
+func (a *app) write(data interface{}, t table) error {
+	switch a.format {
+	case "json":
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+
+	case "csv":
+		w := csv.NewWriter(a.out)
+		w.Write(t.header)
+		w.WriteAll(t.rows)
+		return w.Error()
+
+	default:
+		w := tabwriter.NewWriter(a.out, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func listPublicStatusPages(a *app, args []string) error {
+	fs := newFlagSet(a, "psps list")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	psps, err := a.ur.GetPublicStatusPages(nil)
+	if err != nil {
+		return err
+	}
+	return a.writePublicStatusPages(psps)
+}
+
+func createPublicStatusPage(a *app, args []string) error {
+	fs := newFlagSet(a, "psps create")
+	friendlyName := fs.String("name", "", "friendly name of the status page")
+	monitors := fs.String("monitors", "", "comma separated IDs of the monitors to show (default: all monitors)")
+	customDomain := fs.String("domain", "", "custom domain of the status page")
+	password := fs.String("password", "", "password protecting the status page")
+	order := fs.String("sort", "", "monitor order (name-az, name-za, up-down, down-up)")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 || *friendlyName == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	p := uptimerobot.PublicStatusPage{
+		FriendlyName: *friendlyName,
+		CustomDomain: *customDomain,
+		Password:     *password,
+	}
+
+	var err error
+	if p.Monitors, err = parseIDs(splitList(*monitors)); err != nil {
+		return err
+	}
+
+	if *order != "" {
+		v, err := parseName(pspSortNames, *order)
+		if err != nil {
+			return err
+		}
+		p.Sort = uptimerobot.PublicStatusPageSort(v)
+	}
+
+	created, err := a.ur.NewOrEditPublicStatusPage(p)
+	if err != nil {
+		return err
+	}
+
+	psps, err := a.ur.GetPublicStatusPages([]int{created.ID})
+	if err != nil {
+		return err
+	}
+	return a.writePublicStatusPages(psps)
+}
+
+func syncPublicStatusPage(a *app, args []string) error {
+	fs := newFlagSet(a, "psps sync")
+	types := fs.String("types", "", "comma separated monitor types to show (http, keyword, ping, port)")
+	statuses := fs.String("statuses", "", "comma separated monitor statuses to show")
+	search := fs.String("search", "", "only show monitors with this text in their URL or friendly name")
+	if len(args) == 0 {
+		fmt.Fprintln(a.err, "Usage: uptimerobot psps sync <id> [flags]")
+		return errUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid status page ID %q", args[0])
+	}
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	in, err := monitorFilter(*types, *statuses, *search)
+	if err != nil {
+		return err
+	}
+
+	added, removed, err := a.ur.SyncPublicStatusPageMonitors(id, in)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"MONITOR", "CHANGE"}}
+	for _, id := range added {
+		t.rows = append(t.rows, []string{strconv.Itoa(id), "added"})
+	}
+	for _, id := range removed {
+		t.rows = append(t.rows, []string{strconv.Itoa(id), "removed"})
+	}
+	return a.write(map[string][]int{"added": added, "removed": removed}, t)
+}
+
+func deletePublicStatusPages(a *app, args []string) error {
+	return forEachID(a, "psps delete", args, a.ur.DeletePublicStatusPage)
+}
+
+func (a *app) writePublicStatusPages(psps []uptimerobot.PublicStatusPage) error {
+	t := table{header: []string{"ID", "NAME", "STATUS", "SORT", "MONITORS", "URL"}}
+	for _, p := range psps {
+		monitors := joinInts(p.Monitors)
+		if monitors == "" {
+			monitors = "all"
+		}
+
+		u := p.StandardURL
+		if p.CustomDomain != "" {
+			u = p.CustomDomain
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(p.ID),
+			p.FriendlyName,
+			name(pspStatusNames, int(p.Status)),
+			name(pspSortNames, int(p.Sort)),
+			monitors,
+			u,
+		})
+	}
+	return a.write(psps, t)
+}
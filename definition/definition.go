@@ -0,0 +1,392 @@
+package definition
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition is the content of a definition file
+type Definition struct {
+	// the alert contacts in the order of the file
+	AlertContacts []uptimerobot.AlertContact
+	// the monitors in the order of the file, alert contacts defined in the
+	// file are referenced by FriendlyName with an ID of 0
+	Monitors []uptimerobot.Monitor
+}
+
+// Error is a problem found at a specific position of a definition file
+type Error struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// ErrorList contains all problems found in a definition file
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, 0, len(l))
+	for _, e := range l {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+var (
+	monitorTypes = map[string]int{
+		"http":    int(uptimerobot.MonitorTypeHTTP),
+		"keyword": int(uptimerobot.MonitorTypeKeyword),
+		"ping":    int(uptimerobot.MonitorTypePing),
+		"port":    int(uptimerobot.MonitorTypePort),
+	}
+	monitorSubtypes = map[string]int{
+		"http":   int(uptimerobot.MonitorSubtypeHTTP),
+		"https":  int(uptimerobot.MonitorSubtypeHTTPS),
+		"ftp":    int(uptimerobot.MonitorSubtypeFTP),
+		"smtp":   int(uptimerobot.MonitorSubtypeSMTP),
+		"pop3":   int(uptimerobot.MonitorSubtypePOP3),
+		"imap":   int(uptimerobot.MonitorSubtypeIMAP),
+		"custom": int(uptimerobot.MonitorSubtypeCustomPort),
+	}
+	keywordTypes = map[string]int{
+		"exists":     int(uptimerobot.MonitorKeywordTypeExists),
+		"not-exists": int(uptimerobot.MonitorKeywordTypeNotExists),
+	}
+	alertContactTypes = map[string]int{
+		"sms":        int(uptimerobot.AlertContactTypeSMS),
+		"email":      int(uptimerobot.AlertContactTypeEMail),
+		"twitter-dm": int(uptimerobot.AlertContactTypeTwitterDM),
+		"boxcar":     int(uptimerobot.AlertContactTypeBoxcar),
+		"webhook":    int(uptimerobot.AlertContactTypeWebHook),
+		"pushbullet": int(uptimerobot.AlertContactTypePushBullet),
+		"zapier":     int(uptimerobot.AlertContactTypeZapier),
+		"pushover":   int(uptimerobot.AlertContactTypePushover),
+		"hipchat":    int(uptimerobot.AlertContactTypeHipChat),
+		"slack":      int(uptimerobot.AlertContactTypeSlack),
+	}
+
+	yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+// Load reads and validates the definition file at the given path
+func Load(path string) (*Definition, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, data)
+}
+
+// Parse validates the given definition. The name is used as file name in the
+// reported errors and to detect JSON input by its extension.
+func Parse(name string, data []byte) (*Definition, error) {
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		// JSON is parsed as YAML to get positions of the nodes. Tabs may only
+		// appear as whitespace between tokens in valid JSON but are not allowed
+		// for indentation in YAML.
+		data = bytes.Replace(data, []byte("\t"), []byte(" "), -1)
+	}
+
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(data, root); err != nil {
+		e := &Error{File: name, Line: 1, Msg: err.Error()}
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			e.Line, _ = strconv.Atoi(m[1])
+			e.Msg = m[2]
+		}
+		return nil, ErrorList{e}
+	}
+
+	p := &parser{file: name}
+	def := &Definition{}
+
+	if len(root.Content) > 0 {
+		p.fields(root.Content[0], func(key string, k, v *yaml.Node) {
+			switch key {
+			case "alertContacts":
+				p.list(v, func(n *yaml.Node) { def.AlertContacts = append(def.AlertContacts, p.alertContact(n)) })
+			case "monitors":
+				p.list(v, func(n *yaml.Node) { def.Monitors = append(def.Monitors, p.monitor(n)) })
+			default:
+				p.errorf(k, "unknown field %q", key)
+			}
+		})
+	}
+
+	p.validateReferences()
+
+	if len(p.errs) > 0 {
+		sort.SliceStable(p.errs, func(i, j int) bool {
+			if p.errs[i].Line != p.errs[j].Line {
+				return p.errs[i].Line < p.errs[j].Line
+			}
+			return p.errs[i].Column < p.errs[j].Column
+		})
+		return nil, p.errs
+	}
+	return def, nil
+}
+
+type parser struct {
+	file string
+	errs ErrorList
+
+	contactNodes map[string]*yaml.Node
+	monitorNames map[string]bool
+	references   []reference
+}
+
+type reference struct {
+	name string
+	node *yaml.Node
+}
+
+func (p *parser) errorf(n *yaml.Node, format string, args ...interface{}) {
+	p.errs = append(p.errs, &Error{File: p.file, Line: n.Line, Column: n.Column, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) fields(n *yaml.Node, fn func(key string, k, v *yaml.Node)) {
+	if n.Kind != yaml.MappingNode {
+		p.errorf(n, "expected a mapping")
+		return
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if seen[k.Value] {
+			p.errorf(k, "field %q is set more than once", k.Value)
+			continue
+		}
+		seen[k.Value] = true
+		fn(k.Value, k, v)
+	}
+}
+
+func (p *parser) list(n *yaml.Node, fn func(n *yaml.Node)) {
+	if n.Kind != yaml.SequenceNode {
+		p.errorf(n, "expected a list")
+		return
+	}
+	for _, item := range n.Content {
+		fn(item)
+	}
+}
+
+func (p *parser) str(n *yaml.Node) string {
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		p.errorf(n, "expected a string")
+		return ""
+	}
+	return n.Value
+}
+
+func (p *parser) integer(n *yaml.Node, min, max int) int {
+	i, err := strconv.Atoi(n.Value)
+	if n.Kind != yaml.ScalarNode || err != nil {
+		p.errorf(n, "expected a number")
+		return 0
+	}
+	if i < min || i > max {
+		p.errorf(n, "value %d is out of range (%d-%d)", i, min, max)
+	}
+	return i
+}
+
+func (p *parser) enum(n *yaml.Node, values map[string]int) int {
+	if n.Kind == yaml.ScalarNode {
+		if i, ok := values[strings.ToLower(n.Value)]; ok {
+			return i
+		}
+		if i, err := strconv.Atoi(n.Value); err == nil {
+			for _, v := range values {
+				if v == i {
+					return i
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	p.errorf(n, "unknown value %q, expected one of %s", n.Value, strings.Join(names, ", "))
+	return 0
+}
+
+func (p *parser) alertContact(n *yaml.Node) uptimerobot.AlertContact {
+	ac := uptimerobot.AlertContact{}
+	set := map[string]*yaml.Node{}
+
+	p.fields(n, func(key string, k, v *yaml.Node) {
+		set[key] = v
+		switch key {
+		case "friendlyName":
+			ac.FriendlyName = p.str(v)
+			if len(ac.FriendlyName) > 30 {
+				p.errorf(v, "friendlyName may not have more than 30 chars")
+			}
+		case "type":
+			ac.Type = uptimerobot.AlertContactType(p.enum(v, alertContactTypes))
+		case "value":
+			ac.Value = p.str(v)
+		default:
+			p.errorf(k, "unknown field %q", key)
+		}
+	})
+
+	if n.Kind != yaml.MappingNode {
+		return ac
+	}
+
+	p.require(n, set, "friendlyName", "type", "value")
+
+	if p.contactNodes == nil {
+		p.contactNodes = map[string]*yaml.Node{}
+	}
+	if ac.FriendlyName != "" {
+		if prev, ok := p.contactNodes[ac.FriendlyName]; ok {
+			p.errorf(n, "alert contact %q is already defined at line %d", ac.FriendlyName, prev.Line)
+		} else {
+			p.contactNodes[ac.FriendlyName] = n
+		}
+	}
+	return ac
+}
+
+func (p *parser) monitor(n *yaml.Node) uptimerobot.Monitor {
+	m := uptimerobot.Monitor{}
+	set := map[string]*yaml.Node{}
+
+	p.fields(n, func(key string, k, v *yaml.Node) {
+		set[key] = v
+		switch key {
+		case "friendlyName":
+			m.FriendlyName = p.str(v)
+		case "url":
+			m.URL = p.str(v)
+		case "type":
+			m.Type = uptimerobot.MonitorType(p.enum(v, monitorTypes))
+		case "subtype":
+			m.Subtype = uptimerobot.MonitorSubtype(p.enum(v, monitorSubtypes))
+		case "port":
+			m.Port = p.integer(v, 1, 65535)
+		case "keywordType":
+			m.KeywordType = uptimerobot.MonitorKeywordType(p.enum(v, keywordTypes))
+		case "keywordValue":
+			m.KeywordValue = p.str(v)
+		case "interval":
+			// The file uses minutes, the API seconds
+			m.Interval = p.integer(v, 1, 24*60) * 60
+		case "httpUsername":
+			m.HTTPUsername = p.str(v)
+		case "httpPassword":
+			m.HTTPPassword = p.str(v)
+		case "alertContacts":
+			m.AlertContacts = []uptimerobot.AlertContact{}
+			p.list(v, func(n *yaml.Node) { m.AlertContacts = append(m.AlertContacts, p.monitorAlertContact(n)) })
+		default:
+			p.errorf(k, "unknown field %q", key)
+		}
+	})
+
+	if n.Kind != yaml.MappingNode {
+		return m
+	}
+
+	p.require(n, set, "friendlyName", "url", "type")
+
+	switch {
+	case m.Type == uptimerobot.MonitorTypeKeyword && (m.KeywordType == 0 || m.KeywordValue == ""):
+		p.errorf(n, "keywordType and keywordValue are required for keyword monitors")
+	case m.Type != uptimerobot.MonitorTypeKeyword && set["keywordType"] != nil:
+		p.errorf(set["keywordType"], "keywordType is only allowed for keyword monitors")
+	}
+
+	switch {
+	case m.Type == uptimerobot.MonitorTypePort && m.Subtype == 0:
+		p.errorf(n, "subtype is required for port monitors")
+	case m.Type != uptimerobot.MonitorTypePort && set["subtype"] != nil:
+		p.errorf(set["subtype"], "subtype is only allowed for port monitors")
+	case m.Subtype == uptimerobot.MonitorSubtypeCustomPort && m.Port == 0:
+		p.errorf(n, "port is required for subtype custom")
+	}
+
+	if (m.HTTPUsername == "") != (m.HTTPPassword == "") {
+		p.errorf(n, "httpUsername and httpPassword have to be set together")
+	}
+
+	if p.monitorNames == nil {
+		p.monitorNames = map[string]bool{}
+	}
+	if m.FriendlyName != "" {
+		if p.monitorNames[m.FriendlyName] {
+			p.errorf(n, "monitor %q is defined more than once", m.FriendlyName)
+		}
+		p.monitorNames[m.FriendlyName] = true
+	}
+	return m
+}
+
+func (p *parser) monitorAlertContact(n *yaml.Node) uptimerobot.AlertContact {
+	ac := uptimerobot.AlertContact{}
+	p.fields(n, func(key string, k, v *yaml.Node) {
+		switch key {
+		case "name":
+			ac.FriendlyName = p.str(v)
+			p.references = append(p.references, reference{name: ac.FriendlyName, node: v})
+		case "id":
+			ac.ID = p.integer(v, 1, int(^uint32(0)>>1))
+		case "threshold":
+			ac.Threshold = p.integer(v, 0, 24*60)
+		case "recurrence":
+			ac.Recurrence = p.integer(v, 0, 24*60)
+		default:
+			p.errorf(k, "unknown field %q", key)
+		}
+	})
+
+	if n.Kind == yaml.MappingNode && (ac.FriendlyName == "") == (ac.ID == 0) {
+		p.errorf(n, "exactly one of name and id is required")
+	}
+	return ac
+}
+
+func (p *parser) require(n *yaml.Node, set map[string]*yaml.Node, fields ...string) {
+	for _, field := range fields {
+		if set[field] == nil {
+			p.errorf(n, "field %q is required", field)
+		}
+	}
+}
+
+// validateReferences checks that alert contacts referenced by name are defined
+func (p *parser) validateReferences() {
+	for _, r := range p.references {
+		if r.name == "" {
+			continue
+		}
+		if _, ok := p.contactNodes[r.name]; !ok {
+			p.errorf(r.node, "alert contact %q is not defined", r.name)
+		}
+	}
+}
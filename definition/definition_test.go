@@ -0,0 +1,87 @@
+package definition
+
+import (
+	"strings"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func TestLoadYAML(t *testing.T) {
+	def, err := Load("testdata/monitors.yaml")
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(def.AlertContacts) != 2 || def.AlertContacts[1].Type != uptimerobot.AlertContactTypeSlack {
+		t.Errorf("Unexpected alert contacts: %+v", def.AlertContacts)
+	}
+
+	if len(def.Monitors) != 2 {
+		t.Fatalf("Expected 2 monitors, got %+v", def.Monitors)
+	}
+
+	shop := def.Monitors[0]
+	if shop.Type != uptimerobot.MonitorTypeKeyword || shop.KeywordType != uptimerobot.MonitorKeywordTypeNotExists || shop.KeywordValue != "Internal Error" || shop.Interval != 300 || shop.HTTPPassword != "s3cr3t" {
+		t.Errorf("Unexpected monitor: %+v", shop)
+	}
+
+	if len(shop.AlertContacts) != 2 || shop.AlertContacts[1].FriendlyName != "chat" || shop.AlertContacts[1].Threshold != 5 || shop.AlertContacts[1].Recurrence != 30 {
+		t.Errorf("Unexpected monitor alert contacts: %+v", shop.AlertContacts)
+	}
+
+	mail := def.Monitors[1]
+	if mail.Subtype != uptimerobot.MonitorSubtypeCustomPort || mail.Port != 2525 || mail.AlertContacts[0].ID != 1234567 {
+		t.Errorf("Unexpected monitor: %+v", mail)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	def, err := Load("testdata/monitors.json")
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(def.Monitors) != 1 || def.Monitors[0].Type != uptimerobot.MonitorTypePing || def.Monitors[0].AlertContacts[0].FriendlyName != "oncall" {
+		t.Errorf("Unexpected monitors: %+v", def.Monitors)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := Load("testdata/invalid.yaml")
+	errs, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("Expected an ErrorList, got: %v", err)
+	}
+
+	want := []string{
+		`testdata/invalid.yaml:3:11: unknown value "carrier-pigeon"`,
+		`testdata/invalid.yaml:7:5: keywordType and keywordValue are required for keyword monitors`,
+		`testdata/invalid.yaml:10:5: unknown field "color"`,
+		`testdata/invalid.yaml:12:15: alert contact "nobody" is not defined`,
+		`testdata/invalid.yaml:13:5: field "friendlyName" is required`,
+		`testdata/invalid.yaml:15:11: value 70000 is out of range (1-65535)`,
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %d:\n%s", len(want), len(errs), errs)
+	}
+
+	for i, w := range want {
+		if !strings.HasPrefix(errs[i].Error(), w) {
+			t.Errorf("Error %d: expected %q, got %q", i, w, errs[i])
+		}
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	_, err := Parse("broken.json", []byte("{\n\t\"monitors\": [\n}"))
+	errs, ok := err.(ErrorList)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("Expected a single error, got: %v", err)
+	}
+
+	if errs[0].File != "broken.json" || errs[0].Line != 2 {
+		t.Errorf("Unexpected error position: %s", errs[0])
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Package definition loads monitor and alert contact definitions from YAML or
+JSON files so an inventory of monitors can be kept under version control.
+
+A definition file has two top-level lists, both optional:
+
+	alertContacts:
+	  - friendlyName: oncall            # required, unique, up to 30 characters
+	    type: email                     # required: sms, email, twitter-dm, boxcar, webhook,
+	                                    # pushbullet, zapier, pushover, hipchat, slack
+	    value: ops@example.com          # required: address, phone number, URL, ...
+
+	monitors:
+	  - friendlyName: shop              # required, unique
+	    url: https://shop.example.com/  # required: URL, IP or host name to check
+	    type: keyword                   # required: http, keyword, ping, port
+	    keywordType: not-exists         # keyword monitors: exists, not-exists
+	    keywordValue: Internal Error    # keyword monitors: the text to search for
+	    interval: 5                     # optional: check interval in minutes
+	    httpUsername: monitor           # optional: HTTP basic auth, username and
+	    httpPassword: s3cr3t            # password have to be given together
+	    alertContacts:                  # optional
+	      - name: oncall                # an alert contact defined in this file
+	        threshold: 0                # optional: minutes to wait before alerting
+	        recurrence: 0               # optional: minutes between repeated alerts
+	      - id: 1234567                 # or the ID of an existing alert contact
+
+	  - friendlyName: mail
+	    url: mail.example.com
+	    type: port
+	    subtype: smtp                   # port monitors: http, https, ftp, smtp,
+	                                    # pop3, imap, custom
+	    port: 2525                      # required for subtype custom
+
+Numeric values are accepted for all enumerations as well (type: 2). JSON files
+use the same structure and keys.
+
+Load and Parse validate the whole file and return an ErrorList containing
+every problem found together with the file name, line and column it was found
+at. Alert contacts referenced by name are returned with ID 0, so monitors
+referring to them cannot be passed to uptimerobot.UptimeRobot.NewOrEditMonitor
+before the IDs are filled in. The reconcile package resolves them by name:
+
+	def, err := definition.Load("monitors.yaml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	plan, err := reconcile.NewPlan(ctx, ur, reconcile.Desired{
+		AlertContacts: def.AlertContacts,
+		Monitors:      def.Monitors,
+	}, reconcile.Options{})
+*/
+package definition
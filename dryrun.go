@@ -0,0 +1,208 @@
+package uptimerobot
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Mutation is a change to the account a client in DryRun mode did not send
+type Mutation struct {
+	// the API method (Example: "editMonitor")
+	Method string
+	// the kind of item changed (Example: "Monitor")
+	Resource string
+	// the ID of the changed item, 0 for items which would have been created
+	ID int
+	// the parameters which would have been sent with secrets redacted
+	Params url.Values
+}
+
+// Mutations returns the changes the client did not send while in DryRun mode
+// in the order they were requested
+func (u *UptimeRobot) Mutations() []Mutation {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return append([]Mutation{}, u.mutations...)
+}
+
+// recordMutation is the record function of the dry-run middleware installed
+// by the DryRun setting
+func (u *UptimeRobot) recordMutation(ctx context.Context, call Call) {
+	id, _ := strconv.Atoi(call.Params.Get("id"))
+	if strings.HasPrefix(call.Method, "new") {
+		id = 0
+	}
+
+	m := Mutation{
+		Method:   call.Method,
+		Resource: apiResource(call.Method),
+		ID:       id,
+		Params:   call.Params,
+	}
+
+	u.mu.Lock()
+	u.mutations = append(u.mutations, m)
+	u.mu.Unlock()
+
+	// Skipped calls are always logged, to the standard logger if no Logger
+	// is set
+	l := u.logger()
+	if l == nil {
+		l = stdLogger{}
+	}
+	l.DebugContext(ctx, "UptimeRobot API call skipped (dry run)", "method", m.Method, "id", m.ID, "params", m.Params.Encode())
+}
+
+// dryRunMiddleware returns the innermost middleware of the DryRun setting
+func (u *UptimeRobot) dryRunMiddleware(ctx context.Context) Middleware {
+	return DryRunMiddleware(func(call Call) {
+		u.recordMutation(ctx, call)
+	})
+}
+
+// checkParams checks the parameters of a call changing the account like the
+// API does and returns the error type and parameter it would report, the type
+// is empty if the parameters are valid. Only the values the API checks
+// without looking at the account are checked.
+func checkParams(apiMethod string, params url.Values) (typ, param string) {
+	resource := apiResource(apiMethod)
+	isNew := strings.HasPrefix(apiMethod, "new")
+
+	if !isNew {
+		if params.Get("id") == "" {
+			return "missing_parameter", "id"
+		}
+		if !isIntIn(params.Get("id"), 1, math.MaxInt32) {
+			return "invalid_parameter", "id"
+		}
+	}
+	if strings.HasPrefix(apiMethod, "delete") || strings.HasPrefix(apiMethod, "reset") {
+		return "", ""
+	}
+
+	var checks []paramCheck
+	switch resource {
+	case "Monitor":
+		// Only checked on creation, an edit may change the user name alone
+		if isNew && (params.Get("http_username") == "") != (params.Get("http_password") == "") {
+			return "invalid_parameter", "http_password"
+		}
+		checks = monitorChecks(isNew, params)
+	case "AlertContact":
+		if !isNew && params.Get("value") == "" && params.Get("friendly_name") == "" && params.Get("status") == "" {
+			return "missing_parameter", ""
+		}
+		checks = alertContactChecks(isNew, params)
+	case "MWindow":
+		checks = []paramCheck{
+			{"friendly_name", isNew, nil},
+			{"type", isNew, intIn(1, 4)},
+			{"duration", isNew, intIn(1, math.MaxInt32)},
+			{"start_time", isNew, nil},
+			{"status", false, intIn(0, 1)},
+		}
+		if t := params.Get("type"); t == strconv.Itoa(int(MaintenanceWindowTypeWeekly)) || t == strconv.Itoa(int(MaintenanceWindowTypeMonthly)) {
+			checks = append(checks, paramCheck{"value", isNew, intListIn(1, 31)})
+		}
+	case "PSP":
+		checks = []paramCheck{
+			{"friendly_name", isNew, nil},
+			{"monitors", false, intListIn(0, math.MaxInt32)},
+			{"sort", false, intIn(1, 4)},
+			{"status", false, intIn(0, 1)},
+		}
+	}
+
+	for _, c := range checks {
+		v, ok := params[c.name]
+		switch {
+		case !ok || v[0] == "":
+			if c.required {
+				return "missing_parameter", c.name
+			}
+		case c.valid != nil && !c.valid(v[0]):
+			return "invalid_parameter", c.name
+		}
+	}
+	return "", ""
+}
+
+// paramCheck is the check of a single parameter by checkParams
+type paramCheck struct {
+	name     string
+	required bool
+	// reports whether a given value is valid, nil accepts any value
+	valid func(string) bool
+}
+
+func monitorChecks(isNew bool, params url.Values) []paramCheck {
+	isPort := isNew && params.Get("type") == strconv.Itoa(int(MonitorTypePort))
+	isKeyword := isNew && params.Get("type") == strconv.Itoa(int(MonitorTypeKeyword))
+
+	return []paramCheck{
+		{"friendly_name", isNew, nil},
+		{"url", isNew, nil},
+		{"type", isNew, intIn(1, 4)},
+		{"sub_type", isPort, func(v string) bool {
+			return isIntIn(v, 1, 6) || v == strconv.Itoa(int(MonitorSubtypeCustomPort))
+		}},
+		{"port", false, intIn(1, 65535)},
+		{"keyword_type", isKeyword, intIn(1, 2)},
+		{"keyword_value", isKeyword, nil},
+		{"interval", false, intIn(1, math.MaxInt32)},
+		{"alert_contacts", false, func(v string) bool {
+			for _, c := range strings.Split(v, "-") {
+				f := strings.Split(c, "_")
+				if len(f) != 3 || !isIntIn(f[0], 1, math.MaxInt32) || !isIntIn(f[1], 0, math.MaxInt32) || !isIntIn(f[2], 0, math.MaxInt32) {
+					return false
+				}
+			}
+			return true
+		}},
+		{"mwindows", false, intListIn(1, math.MaxInt32)},
+		{"status", false, intIn(0, 1)},
+	}
+}
+
+func alertContactChecks(isNew bool, params url.Values) []paramCheck {
+	if !isNew {
+		return []paramCheck{{"status", false, intIn(0, 2)}}
+	}
+
+	var isValue func(string) bool
+	if params.Get("type") == strconv.Itoa(int(AlertContactTypeEMail)) {
+		isValue = func(v string) bool { return strings.Contains(v, "@") }
+	}
+	return []paramCheck{
+		{"type", true, intIn(1, 10)},
+		{"value", true, isValue},
+	}
+}
+
+// isIntIn reports whether v is an integer between min and max
+func isIntIn(v string, min, max int) bool {
+	i, err := strconv.Atoi(v)
+	return err == nil && i >= min && i <= max
+}
+
+// intIn returns a check accepting integers between min and max
+func intIn(min, max int) func(string) bool {
+	return func(v string) bool { return isIntIn(v, min, max) }
+}
+
+// intListIn returns a check accepting a "-" separated list of integers
+// between min and max
+func intListIn(min, max int) func(string) bool {
+	return func(v string) bool {
+		for _, i := range strings.Split(v, "-") {
+			if !isIntIn(i, min, max) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,162 @@
+package uptimerobot_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestDryRun(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	ops := srv.AddAlertContact(uptimerobot.AlertContact{Type: uptimerobot.AlertContactTypeEMail, Value: "ops@example.com"})
+
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+
+	ur := srv.Client()
+	ur.DryRun = true
+
+	mons, err := ur.GetMonitors(nil)
+	if err != nil || len(mons) != 1 {
+		t.Fatalf("Expected reads to reach the API, got %+v (%v)", mons, err)
+	}
+
+	if _, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "api"}); err == nil {
+		t.Errorf("Expected invalid input to be rejected")
+	}
+
+	m, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP, HTTPUsername: "api", HTTPPassword: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if m.FriendlyName != "api" || m.Status != uptimerobot.MonitorStatusNotCheckedYet {
+		t.Errorf("Unexpected synthetic monitor: %+v", m)
+	}
+
+	edited := web
+	edited.FriendlyName = "www"
+	if m, err := ur.NewOrEditMonitor(edited); err != nil || m.ID != web.ID {
+		t.Errorf("Unexpected synthetic monitor %+v (%v)", m, err)
+	}
+
+	if err := ur.ResetMonitor(web.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if err := ur.DeleteMonitor(web.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	c, err := ur.NewAlertContact(uptimerobot.AlertContact{Type: uptimerobot.AlertContactTypeEMail, Value: "dev@example.com"})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if c.Status != uptimerobot.AlertContactStatusNotActivated {
+		t.Errorf("Unexpected synthetic alert contact: %+v", c)
+	}
+
+	if err := ur.DeleteAlertContact(ops.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if mons := srv.Monitors(); len(mons) != 1 || mons[0].FriendlyName != "web" {
+		t.Errorf("Expected the monitors to be unchanged, got %+v", mons)
+	}
+	if contacts := srv.AlertContacts(); len(contacts) != 1 {
+		t.Errorf("Expected the alert contacts to be unchanged, got %+v", contacts)
+	}
+
+	expected := []uptimerobot.Mutation{
+		{Method: "newMonitor", Resource: "Monitor"},
+		{Method: "editMonitor", Resource: "Monitor", ID: web.ID},
+		{Method: "resetMonitor", Resource: "Monitor", ID: web.ID},
+		{Method: "deleteMonitor", Resource: "Monitor", ID: web.ID},
+		{Method: "newAlertContact", Resource: "AlertContact"},
+		{Method: "deleteAlertContact", Resource: "AlertContact", ID: ops.ID},
+	}
+
+	mutations := ur.Mutations()
+	if len(mutations) != len(expected) {
+		t.Fatalf("Expected %d mutations, got %+v", len(expected), mutations)
+	}
+	for i, e := range expected {
+		if m := mutations[i]; m.Method != e.Method || m.Resource != e.Resource || m.ID != e.ID {
+			t.Errorf("Unexpected mutation %d: %+v", i, m)
+		}
+	}
+
+	if p := mutations[0].Params; p.Get("friendly_name") != "api" || p.Get("http_password") != "REDACTED" || p.Get("api_key") != "REDACTED" {
+		t.Errorf("Unexpected parameters: %v", p)
+	}
+
+	if out := buf.String(); strings.Count(out, "skipped (dry run)") != len(expected) || strings.Contains(out, "s3cr3t") {
+		t.Errorf("Unexpected log output: %s", out)
+	}
+}
+
+func TestDryRunValidatesParams(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+
+	ur := srv.Client()
+	ur.DryRun = true
+
+	for _, c := range []struct {
+		name  string
+		call  func() error
+		code  uptimerobot.APIError
+		param string
+	}{
+		{"unknown monitor type", func() error {
+			_, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: 7})
+			return err
+		}, uptimerobot.ErrorMonitorTypeInvalid, "type"},
+		{"port monitor without sub type", func() error {
+			_, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "smtp", URL: "mail.example.com", Type: uptimerobot.MonitorTypePort})
+			return err
+		}, uptimerobot.ErrorMonitorSubTypeRequired, "sub_type"},
+		{"unresolved alert contact", func() error {
+			_, err := ur.NewOrEditMonitor(uptimerobot.Monitor{ID: web.ID, FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, AlertContacts: []uptimerobot.AlertContact{{FriendlyName: "ops"}}})
+			return err
+		}, uptimerobot.ErrorMonitorAlertContactsValueInvalid, "alert_contacts"},
+		{"monitor without id", func() error {
+			return ur.DeleteMonitor(0)
+		}, uptimerobot.ErrorMonitorIDShouldBeInteger, "id"},
+		{"alert contact with invalid e-mail", func() error {
+			_, err := ur.NewAlertContact(uptimerobot.AlertContact{Type: uptimerobot.AlertContactTypeEMail, Value: "ops"})
+			return err
+		}, uptimerobot.ErrorAlertContactValueShouldBeEMail, "value"},
+		{"unknown alert contact type", func() error {
+			_, err := ur.NewAlertContact(uptimerobot.AlertContact{Type: 42, Value: "ops@example.com"})
+			return err
+		}, uptimerobot.ErrorAlertContactTypeNotSupported, "type"},
+		{"unknown status page sort", func() error {
+			_, err := ur.NewOrEditPublicStatusPage(uptimerobot.PublicStatusPage{FriendlyName: "status", Sort: 9})
+			return err
+		}, uptimerobot.ErrorPSPValueInvalid, "sort"},
+	} {
+		err := c.call()
+		if !errors.Is(err, c.code) {
+			t.Errorf("%s: Expected %d, got %v", c.name, c.code, err)
+			continue
+		}
+
+		var apiErr *uptimerobot.Error
+		if !errors.As(err, &apiErr) || apiErr.ParameterName != c.param {
+			t.Errorf("%s: Expected the error to name %s, got %+v", c.name, c.param, apiErr)
+		}
+	}
+
+	if mutations := ur.Mutations(); len(mutations) != 0 {
+		t.Errorf("Expected rejected calls not to be recorded, got %+v", mutations)
+	}
+}
@@ -1,42 +1,232 @@
 package uptimerobot
 
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// APIError identifies an error reported by the UptimeRobot API. The codes are
+// the ones of the legacy v1 API, the errors of the v2 API are mapped to them by
+// their type and parameter. Errors the v1 API did not know get client-defined
+// codes from 231 on. It implements the error interface so it can be
+// matched with errors.Is against the Error type returned by the client methods.
 type APIError int
 
 const (
 	ErrorAPIKeyWrongFormat APIError = 100 // apiKey not mentioned or in a wrong format
-	ErrorAPIKeyWrong                = 101 // apiKey is wrong
-	ErrorWrongFormat                = 102 // format is wrong (should be xml or json)
-	ErrorNoSuchMethod               = 103 // No such method exists
-
-	ErrorMonitorIDShouldBeInteger                  = 200 // monitorID(s) should be integers
-	ErrorMonitorURLInvalid                         = 201 // monitorUrl is invalid
-	ErrorMonitorTypeInvalid                        = 202 // monitorType is invalid
-	ErrorMonitorSubTypeInvalid                     = 203 // monitorSubType is invalid
-	ErrorMonitorKeywordTypeInvalid                 = 204 // monitorKeywordType is invalid
-	ErrorMonitorPortInvalid                        = 205 // monitorPort is invalid
-	ErrorMonitorFriendlyNameRequired               = 206 // monitorFriendlyName is required
-	ErrorMonitorAlreadyExists                      = 207 // The monitor already exists
-	ErrorMonitorSubTypeRequired                    = 208 // monitorSubType is required for this type of monitors
-	ErrorMonitorKeywordTypeAndKeywordValueRequired = 209 // monitorKeyWordType and monitorKeyWordValue are required for this type of monitors
-	ErrorMonitorIDNoExists                         = 210 // monitorID doesn't exist
-	ErrorMonitorIDRequired                         = 211 // monitorID is required
-	ErrorAccountHasNoMonitors                      = 212 // The account has no monitors
-	ErrorNoEditsFound                              = 213 // At least one of the parameters to be edited are required
-	ErrorHTTPCredentialsMismatch                   = 214 // monitorHTTPUsername and monitorHTTPPassword should both be empty or have values
-	ErrorInvalidAPIScope                           = 215 // monitor specific apiKeys can only use getMonitors method
-	ErrorEMailInUse                                = 216 // A user with this e-mail already exists
-	ErrorFirstLastNameEMailRequired                = 217 // userFirstLastName and userEmail are both required
-	ErrorEMailFormatInvalid                        = 218 // userEmail is not in the right e-mail format
-	ErrorUserCreateNotAllowed                      = 219 // This account is not authorized to create users
-	ErrorMonitorAlertContactsValueInvalid          = 220 // monitorAlertContacts value is wrong
-	ErrorNoAlertContactsFound                      = 221 // The account has no alert contacts
-	ErrorAlertContactIDShoudBeInteger              = 222 // alertcontactID(s) should be integers
-	ErrorAlertContactTypeAndValueRequired          = 223 // alertContactType and alertContactValue are both required
-	ErrorAlertContactTypeNotSupported              = 224 // This alertContactType is not supported"
-	ErrorAlertContactAlreadyExists                 = 225 // The alert contact already exists
-	ErrorAlertContactDoesNotFollowUptimeRobot      = 226 // The alert contact is not following @uptimerobot Twitter user. It is required so that the Twitter direct messages (DM) can be sent
-	ErrorBoxcarUserNotExists                       = 227 // The Boxcar user mentioned does not exist
-	ErrorBoxcarUserNotAdded                        = 228 // The Boxcar alert contact couldn't be added, please try again later
-	ErrorAlertContactIDNotExists                   = 229 // alertContactID doesn't exist
-	ErrorAlertContactValueShouldBeEMail            = 230 // alertContactValue should be a valid e-mail for this alertContactType
+	ErrorAPIKeyWrong       APIError = 101 // apiKey is wrong
+	ErrorWrongFormat       APIError = 102 // format is wrong (should be xml or json)
+	ErrorNoSuchMethod      APIError = 103 // No such method exists
+
+	ErrorMonitorIDShouldBeInteger                  APIError = 200 // monitorID(s) should be integers
+	ErrorMonitorURLInvalid                         APIError = 201 // monitorUrl is invalid
+	ErrorMonitorTypeInvalid                        APIError = 202 // monitorType is invalid
+	ErrorMonitorSubTypeInvalid                     APIError = 203 // monitorSubType is invalid
+	ErrorMonitorKeywordTypeInvalid                 APIError = 204 // monitorKeywordType is invalid
+	ErrorMonitorPortInvalid                        APIError = 205 // monitorPort is invalid
+	ErrorMonitorFriendlyNameRequired               APIError = 206 // monitorFriendlyName is required
+	ErrorMonitorAlreadyExists                      APIError = 207 // The monitor already exists
+	ErrorMonitorSubTypeRequired                    APIError = 208 // monitorSubType is required for this type of monitors
+	ErrorMonitorKeywordTypeAndKeywordValueRequired APIError = 209 // monitorKeyWordType and monitorKeyWordValue are required for this type of monitors
+	ErrorMonitorIDNoExists                         APIError = 210 // monitorID doesn't exist
+	ErrorMonitorIDRequired                         APIError = 211 // monitorID is required
+	ErrorAccountHasNoMonitors                      APIError = 212 // The account has no monitors
+	ErrorNoEditsFound                              APIError = 213 // At least one of the parameters to be edited are required
+	ErrorHTTPCredentialsMismatch                   APIError = 214 // monitorHTTPUsername and monitorHTTPPassword should both be empty or have values
+	ErrorInvalidAPIScope                           APIError = 215 // monitor specific apiKeys can only use getMonitors method
+	ErrorEMailInUse                                APIError = 216 // A user with this e-mail already exists
+	ErrorFirstLastNameEMailRequired                APIError = 217 // userFirstLastName and userEmail are both required
+	ErrorEMailFormatInvalid                        APIError = 218 // userEmail is not in the right e-mail format
+	ErrorUserCreateNotAllowed                      APIError = 219 // This account is not authorized to create users
+	ErrorMonitorAlertContactsValueInvalid          APIError = 220 // monitorAlertContacts value is wrong
+	ErrorNoAlertContactsFound                      APIError = 221 // The account has no alert contacts
+	ErrorAlertContactIDShoudBeInteger              APIError = 222 // alertcontactID(s) should be integers
+	ErrorAlertContactTypeAndValueRequired          APIError = 223 // alertContactType and alertContactValue are both required
+	ErrorAlertContactTypeNotSupported              APIError = 224 // This alertContactType is not supported"
+	ErrorAlertContactAlreadyExists                 APIError = 225 // The alert contact already exists
+	ErrorAlertContactDoesNotFollowUptimeRobot      APIError = 226 // The alert contact is not following @uptimerobot Twitter user. It is required so that the Twitter direct messages (DM) can be sent
+	ErrorBoxcarUserNotExists                       APIError = 227 // The Boxcar user mentioned does not exist
+	ErrorBoxcarUserNotAdded                        APIError = 228 // The Boxcar alert contact couldn't be added, please try again later
+	ErrorAlertContactIDNotExists                   APIError = 229 // alertContactID doesn't exist
+	ErrorAlertContactValueShouldBeEMail            APIError = 230 // alertContactValue should be a valid e-mail for this alertContactType
 )
+
+// Errors only reported by the v2 API. The codes are defined by this client, the
+// API does not return them.
+const (
+	ErrorMWindowIDNotExists  APIError = 231 // The maintenance window doesn't exist
+	ErrorMWindowValueInvalid APIError = 232 // type, value, start_time or duration of the maintenance window is wrong
+	ErrorPSPIDNotExists      APIError = 233 // The public status page doesn't exist
+	ErrorPSPValueInvalid     APIError = 234 // friendly_name, monitors or sort of the public status page is wrong
+)
+
+var apiErrorMessages = map[APIError]string{
+	ErrorAPIKeyWrongFormat:                         "apiKey not mentioned or in a wrong format",
+	ErrorAPIKeyWrong:                               "apiKey is wrong",
+	ErrorWrongFormat:                               "format is wrong (should be xml or json)",
+	ErrorNoSuchMethod:                              "No such method exists",
+	ErrorMonitorIDShouldBeInteger:                  "monitorID(s) should be integers",
+	ErrorMonitorURLInvalid:                         "monitorUrl is invalid",
+	ErrorMonitorTypeInvalid:                        "monitorType is invalid",
+	ErrorMonitorSubTypeInvalid:                     "monitorSubType is invalid",
+	ErrorMonitorKeywordTypeInvalid:                 "monitorKeywordType is invalid",
+	ErrorMonitorPortInvalid:                        "monitorPort is invalid",
+	ErrorMonitorFriendlyNameRequired:               "monitorFriendlyName is required",
+	ErrorMonitorAlreadyExists:                      "The monitor already exists",
+	ErrorMonitorSubTypeRequired:                    "monitorSubType is required for this type of monitors",
+	ErrorMonitorKeywordTypeAndKeywordValueRequired: "monitorKeyWordType and monitorKeyWordValue are required for this type of monitors",
+	ErrorMonitorIDNoExists:                         "monitorID doesn't exist",
+	ErrorMonitorIDRequired:                         "monitorID is required",
+	ErrorAccountHasNoMonitors:                      "The account has no monitors",
+	ErrorNoEditsFound:                              "At least one of the parameters to be edited are required",
+	ErrorHTTPCredentialsMismatch:                   "monitorHTTPUsername and monitorHTTPPassword should both be empty or have values",
+	ErrorInvalidAPIScope:                           "monitor specific apiKeys can only use getMonitors method",
+	ErrorEMailInUse:                                "A user with this e-mail already exists",
+	ErrorFirstLastNameEMailRequired:                "userFirstLastName and userEmail are both required",
+	ErrorEMailFormatInvalid:                        "userEmail is not in the right e-mail format",
+	ErrorUserCreateNotAllowed:                      "This account is not authorized to create users",
+	ErrorMonitorAlertContactsValueInvalid:          "monitorAlertContacts value is wrong",
+	ErrorNoAlertContactsFound:                      "The account has no alert contacts",
+	ErrorAlertContactIDShoudBeInteger:              "alertcontactID(s) should be integers",
+	ErrorAlertContactTypeAndValueRequired:          "alertContactType and alertContactValue are both required",
+	ErrorAlertContactTypeNotSupported:              "This alertContactType is not supported",
+	ErrorAlertContactAlreadyExists:                 "The alert contact already exists",
+	ErrorAlertContactDoesNotFollowUptimeRobot:      "The alert contact is not following @uptimerobot Twitter user. It is required so that the Twitter direct messages (DM) can be sent",
+	ErrorBoxcarUserNotExists:                       "The Boxcar user mentioned does not exist",
+	ErrorBoxcarUserNotAdded:                        "The Boxcar alert contact couldn't be added, please try again later",
+	ErrorAlertContactIDNotExists:                   "alertContactID doesn't exist",
+	ErrorAlertContactValueShouldBeEMail:            "alertContactValue should be a valid e-mail for this alertContactType",
+	ErrorMWindowIDNotExists:                        "The maintenance window doesn't exist",
+	ErrorMWindowValueInvalid:                       "type, value, start_time or duration of the maintenance window is wrong",
+	ErrorPSPIDNotExists:                            "The public status page doesn't exist",
+	ErrorPSPValueInvalid:                           "friendly_name, monitors or sort of the public status page is wrong",
+}
+
+func (e APIError) Error() string {
+	if msg, ok := apiErrorMessages[e]; ok {
+		return msg
+	}
+	return fmt.Sprintf("Unknown API error %d", int(e))
+}
+
+// Error is returned by the client methods when the API did not report success.
+// Use errors.As to access the details or errors.Is to compare it against one of
+// the APIError codes.
+type Error struct {
+	// the API method which has been called (Example: "getMonitors")
+	Method string
+	// the HTTP status code of the response
+	StatusCode int
+	// the status reported by the API, usually "fail" (empty if the response
+	// could not be decoded)
+	Stat string
+	// the error code matching the reported error (0 if unknown)
+	Code APIError
+	// the error type reported by the API (Example: "invalid_parameter")
+	Type string
+	// the parameter the error refers to and the value passed for it (secrets
+	// are redacted)
+	ParameterName string
+	PassedValue   string
+	// the error message reported by the API
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Stat == "" {
+		return fmt.Sprintf("Got unexpected HTTP status: %d %s (%s)", e.StatusCode, http.StatusText(e.StatusCode), e.Method)
+	}
+
+	msg := e.Message
+	if msg == "" && e.Code != 0 {
+		msg = e.Code.Error()
+	}
+
+	if e.Code == 0 && e.Type != "" {
+		return fmt.Sprintf("Got unexpected status: %s (%s: %s %s: %s)", e.Stat, e.Method, e.Type, e.ParameterName, msg)
+	}
+	return fmt.Sprintf("Got unexpected status: %s (%s: %d %s)", e.Stat, e.Method, e.Code, msg)
+}
+
+// Unwrap returns the APIError code so errors.Is can match it
+func (e *Error) Unwrap() error {
+	if e.Code == 0 {
+		return nil
+	}
+	return e.Code
+}
+
+// v2ErrorCodes maps the error type and parameter reported by the v2 API to the
+// error codes. Keys are prefixed with the resource of the method or "*" for
+// errors every method reports.
+var v2ErrorCodes = map[string]APIError{
+	"* missing_parameter api_key": ErrorAPIKeyWrongFormat,
+	"* invalid_parameter api_key": ErrorAPIKeyWrong,
+	"* invalid_parameter format":  ErrorWrongFormat,
+	"* not_found method":          ErrorNoSuchMethod,
+
+	"Monitor invalid_parameter monitors":            ErrorMonitorIDShouldBeInteger,
+	"Monitor invalid_parameter id":                  ErrorMonitorIDShouldBeInteger,
+	"Monitor missing_parameter id":                  ErrorMonitorIDRequired,
+	"Monitor not_found id":                          ErrorMonitorIDNoExists,
+	"Monitor invalid_parameter url":                 ErrorMonitorURLInvalid,
+	"Monitor missing_parameter url":                 ErrorMonitorURLInvalid,
+	"Monitor invalid_parameter type":                ErrorMonitorTypeInvalid,
+	"Monitor missing_parameter type":                ErrorMonitorTypeInvalid,
+	"Monitor invalid_parameter sub_type":            ErrorMonitorSubTypeInvalid,
+	"Monitor missing_parameter sub_type":            ErrorMonitorSubTypeRequired,
+	"Monitor invalid_parameter keyword_type":        ErrorMonitorKeywordTypeInvalid,
+	"Monitor missing_parameter keyword_type":        ErrorMonitorKeywordTypeAndKeywordValueRequired,
+	"Monitor missing_parameter keyword_value":       ErrorMonitorKeywordTypeAndKeywordValueRequired,
+	"Monitor invalid_parameter port":                ErrorMonitorPortInvalid,
+	"Monitor missing_parameter friendly_name":       ErrorMonitorFriendlyNameRequired,
+	"Monitor already_exists ":                       ErrorMonitorAlreadyExists,
+	"Monitor missing_parameter ":                    ErrorNoEditsFound,
+	"Monitor invalid_parameter http_username":       ErrorHTTPCredentialsMismatch,
+	"Monitor invalid_parameter http_password":       ErrorHTTPCredentialsMismatch,
+	"Monitor invalid_parameter alert_contacts":      ErrorMonitorAlertContactsValueInvalid,
+	"Monitor invalid_parameter mwindows":            ErrorMWindowIDNotExists,
+	"Monitor not_found mwindows":                    ErrorMWindowIDNotExists,
+	"Monitor invalid_parameter status":              ErrorNoEditsFound,
+	"AlertContact invalid_parameter alert_contacts": ErrorAlertContactIDShoudBeInteger,
+	"AlertContact invalid_parameter id":             ErrorAlertContactIDShoudBeInteger,
+	"AlertContact not_found id":                     ErrorAlertContactIDNotExists,
+	"AlertContact missing_parameter type":           ErrorAlertContactTypeAndValueRequired,
+	"AlertContact missing_parameter value":          ErrorAlertContactTypeAndValueRequired,
+	"AlertContact invalid_parameter type":           ErrorAlertContactTypeNotSupported,
+	"AlertContact invalid_parameter value":          ErrorAlertContactValueShouldBeEMail,
+	"AlertContact already_exists ":                  ErrorAlertContactAlreadyExists,
+	"AlertContact missing_parameter ":               ErrorNoEditsFound,
+	"AlertContact invalid_parameter status":         ErrorNoEditsFound,
+	"MWindow not_found *":                           ErrorMWindowIDNotExists,
+	"PSP not_found id":                              ErrorPSPIDNotExists,
+	"MWindow invalid_parameter *":                   ErrorMWindowValueInvalid,
+	"MWindow missing_parameter *":                   ErrorMWindowValueInvalid,
+	"PSP invalid_parameter *":                       ErrorPSPValueInvalid,
+	"PSP missing_parameter *":                       ErrorPSPValueInvalid,
+}
+
+// apiResource returns the resource an API method works on, which is the
+// method without its verb and plural (Example: "getAlertContacts" =>
+// "AlertContact")
+func apiResource(apiMethod string) string {
+	return strings.TrimSuffix(strings.TrimLeftFunc(apiMethod, unicode.IsLower), "s")
+}
+
+// errorCode returns the error code of an error reported by the v2 API
+func errorCode(apiMethod, typ, param string) APIError {
+	resource := apiResource(apiMethod)
+
+	for _, key := range []string{
+		resource + " " + typ + " " + param,
+		resource + " " + typ + " *",
+		"* " + typ + " " + param,
+	} {
+		if code, ok := v2ErrorCodes[key]; ok {
+			return code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,222 @@
+// Package exporter exposes the monitors and the account of UptimeRobot as
+// Prometheus metrics.
+//
+// The Collector polls the API in the background and serves the data of the
+// last poll on every scrape, so the number of requests sent to the API does
+// not depend on the number of Prometheus servers or their scrape interval:
+//
+//	c := exporter.NewCollector(ur)
+//	prometheus.MustRegister(c)
+//	go c.Run(ctx)
+//	http.Handle("/metrics", promhttp.Handler())
+//
+// Exposed metrics, the monitor metrics are labeled with the id, name, type
+// and url of the monitor:
+//
+//	uptimerobot_monitor_status                 status of the monitor (0 paused, 1 not checked yet, 2 up, 8 seems down, 9 down)
+//	uptimerobot_monitor_uptime_ratio           uptime ratio (0-1) of the period given by the period label ("all" or days like "7d")
+//	uptimerobot_monitor_response_time_seconds  latest response time of the monitor
+//	uptimerobot_account_monitor_limit          max number of monitors of the account
+//	uptimerobot_account_monitor_interval_seconds  min check interval of the account
+//	uptimerobot_account_monitors               number of monitors per status ("up", "down" or "paused")
+//	uptimerobot_poll_success                   whether the last poll of the API succeeded
+//	uptimerobot_poll_timestamp_seconds         time of the last successful poll
+package exporter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/internal/poll"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// minInterval keeps the collector from exceeding the rate limit of the API
+const minInterval = time.Minute
+
+var (
+	monitorLabels = []string{"id", "name", "type", "url"}
+
+	monitorTypeNames = map[uptimerobot.MonitorType]string{
+		uptimerobot.MonitorTypeHTTP:    "http",
+		uptimerobot.MonitorTypeKeyword: "keyword",
+		uptimerobot.MonitorTypePing:    "ping",
+		uptimerobot.MonitorTypePort:    "port",
+	}
+
+	statusDesc = prometheus.NewDesc(
+		"uptimerobot_monitor_status",
+		"Status of the monitor (0 paused, 1 not checked yet, 2 up, 8 seems down, 9 down)",
+		monitorLabels, nil,
+	)
+	uptimeRatioDesc = prometheus.NewDesc(
+		"uptimerobot_monitor_uptime_ratio",
+		"Uptime ratio (0-1) of the monitor in the period, all-time or the number of days",
+		append(monitorLabels, "period"), nil,
+	)
+	responseTimeDesc = prometheus.NewDesc(
+		"uptimerobot_monitor_response_time_seconds",
+		"Latest response time of the monitor",
+		monitorLabels, nil,
+	)
+	monitorLimitDesc = prometheus.NewDesc(
+		"uptimerobot_account_monitor_limit",
+		"Max number of monitors of the account",
+		nil, nil,
+	)
+	monitorIntervalDesc = prometheus.NewDesc(
+		"uptimerobot_account_monitor_interval_seconds",
+		"Min check interval supported by the account",
+		nil, nil,
+	)
+	accountMonitorsDesc = prometheus.NewDesc(
+		"uptimerobot_account_monitors",
+		"Number of monitors of the account per status",
+		[]string{"status"}, nil,
+	)
+	pollSuccessDesc = prometheus.NewDesc(
+		"uptimerobot_poll_success",
+		"Whether the last poll of the UptimeRobot API succeeded",
+		nil, nil,
+	)
+	pollTimestampDesc = prometheus.NewDesc(
+		"uptimerobot_poll_timestamp_seconds",
+		"Time of the last successful poll of the UptimeRobot API",
+		nil, nil,
+	)
+)
+
+// Collector is a prometheus.Collector serving the data of the last poll of
+// the API
+type Collector struct {
+	// the time between two polls, at least one minute (default: five minutes)
+	Interval time.Duration
+	// the periods in days to expose uptime ratios for besides the all-time
+	// ratio (Example: []int{7, 30})
+	CustomUptimeRatios []int
+	// Logger receives the failed polls (default: the Logger of the client, the
+	// standard logger if neither is set)
+	Logger uptimerobot.Logger
+
+	ur  *uptimerobot.UptimeRobot
+	now func() time.Time
+
+	mu       sync.RWMutex
+	monitors []uptimerobot.Monitor
+	account  *uptimerobot.AccountDetail
+	polled   time.Time
+	pollErr  error
+}
+
+// NewCollector creates a collector polling the API with the given client
+func NewCollector(ur *uptimerobot.UptimeRobot) *Collector {
+	return &Collector{
+		Interval: 5 * time.Minute,
+		ur:       ur,
+		now:      time.Now,
+	}
+}
+
+// Poll fetches the monitors and the account details and replaces the data
+// served by the collector. If the API fails, the data of the previous poll
+// stays in place and uptimerobot_poll_success drops to 0.
+func (c *Collector) Poll(ctx context.Context) error {
+	monitors, err := c.ur.GetMonitorsContext(ctx, &uptimerobot.GetMonitorsInput{
+		CustomUptimeRatio:  c.CustomUptimeRatios,
+		ResponseTimes:      true,
+		ResponseTimesLimit: 1,
+	})
+	if err == nil {
+		var account *uptimerobot.AccountDetail
+		if account, err = c.ur.GetAccountDetailsContext(ctx); err == nil {
+			c.mu.Lock()
+			c.monitors, c.account, c.polled = monitors, account, c.now()
+			c.mu.Unlock()
+		}
+	}
+
+	c.mu.Lock()
+	c.pollErr = err
+	c.mu.Unlock()
+	return err
+}
+
+// Run polls the API every Interval until the context is cancelled, failed
+// polls are logged and retried with the next one
+func (c *Collector) Run(ctx context.Context) error {
+	interval := c.Interval
+	if interval < minInterval {
+		interval = minInterval
+	}
+
+	return poll.Run(ctx, interval, poll.Logger(c.Logger, c.ur), "Polling the UptimeRobot API failed", c.Poll)
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		statusDesc, uptimeRatioDesc, responseTimeDesc,
+		monitorLimitDesc, monitorIntervalDesc, accountMonitorsDesc,
+		pollSuccessDesc, pollTimestampDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	success := 0.0
+	if c.pollErr == nil && !c.polled.IsZero() {
+		success = 1
+	}
+	ch <- prometheus.MustNewConstMetric(pollSuccessDesc, prometheus.GaugeValue, success)
+
+	if c.polled.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(pollTimestampDesc, prometheus.GaugeValue, float64(c.polled.UnixNano())/1e9)
+
+	for _, m := range c.monitors {
+		labels := []string{strconv.Itoa(m.ID), m.FriendlyName, monitorTypeNames[m.Type], m.URL}
+
+		ch <- prometheus.MustNewConstMetric(statusDesc, prometheus.GaugeValue, float64(m.Status), labels...)
+		ch <- prometheus.MustNewConstMetric(uptimeRatioDesc, prometheus.GaugeValue, m.AlltimeUptimeRatio/100, append(labels, "all")...)
+
+		for i, ratio := range m.CustomUptimeRatios {
+			if i >= len(c.CustomUptimeRatios) {
+				break
+			}
+			period := strconv.Itoa(c.CustomUptimeRatios[i]) + "d"
+			ch <- prometheus.MustNewConstMetric(uptimeRatioDesc, prometheus.GaugeValue, ratio/100, append(labels, period)...)
+		}
+
+		if rt, ok := latestResponseTime(m.ResponseTimes); ok {
+			ch <- prometheus.MustNewConstMetric(responseTimeDesc, prometheus.GaugeValue, float64(rt.Value)/1000, labels...)
+		}
+	}
+
+	if a := c.account; a != nil {
+		ch <- prometheus.MustNewConstMetric(monitorLimitDesc, prometheus.GaugeValue, float64(a.MonitorLimit))
+		ch <- prometheus.MustNewConstMetric(monitorIntervalDesc, prometheus.GaugeValue, float64(a.MonitorInterval*60))
+		ch <- prometheus.MustNewConstMetric(accountMonitorsDesc, prometheus.GaugeValue, float64(a.UpMonitors), "up")
+		ch <- prometheus.MustNewConstMetric(accountMonitorsDesc, prometheus.GaugeValue, float64(a.DownMonitors), "down")
+		ch <- prometheus.MustNewConstMetric(accountMonitorsDesc, prometheus.GaugeValue, float64(a.PausedMonitors), "paused")
+	}
+}
+
+// latestResponseTime returns the newest of the response times
+func latestResponseTime(rts []uptimerobot.ResponseTime) (uptimerobot.ResponseTime, bool) {
+	var latest uptimerobot.ResponseTime
+	for i, rt := range rts {
+		if i == 0 || time.Time(rt.DateTime).After(time.Time(latest.DateTime)) {
+			latest = rt
+		}
+	}
+	return latest, len(rts) > 0
+}
@@ -0,0 +1,128 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	checked := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName:       "shop",
+		URL:                "https://shop.example.com/",
+		Type:               uptimerobot.MonitorTypeHTTP,
+		Status:             uptimerobot.MonitorStatusUp,
+		AlltimeUptimeRatio: 99.5,
+		CustomUptimeRatios: []float64{100, 99.9},
+		ResponseTimes: []uptimerobot.ResponseTime{
+			{DateTime: uptimerobot.UptimeRobotDate(checked.Add(time.Minute)), Value: 250},
+			{DateTime: uptimerobot.UptimeRobotDate(checked), Value: 900},
+		},
+	})
+
+	c := NewCollector(srv.Client())
+	c.CustomUptimeRatios = []int{7, 30}
+	c.now = func() time.Time { return checked.Add(time.Hour) }
+
+	// Nothing but the poll status is served before the first poll
+	if n := testutil.CollectAndCount(c); n != 1 {
+		t.Errorf("Expected 1 metric before the first poll, got %d", n)
+	}
+
+	if err := c.Poll(context.Background()); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	expected := `
+# HELP uptimerobot_account_monitor_interval_seconds Min check interval supported by the account
+# TYPE uptimerobot_account_monitor_interval_seconds gauge
+uptimerobot_account_monitor_interval_seconds 300
+# HELP uptimerobot_account_monitor_limit Max number of monitors of the account
+# TYPE uptimerobot_account_monitor_limit gauge
+uptimerobot_account_monitor_limit 50
+# HELP uptimerobot_account_monitors Number of monitors of the account per status
+# TYPE uptimerobot_account_monitors gauge
+uptimerobot_account_monitors{status="down"} 0
+uptimerobot_account_monitors{status="paused"} 0
+uptimerobot_account_monitors{status="up"} 1
+# HELP uptimerobot_monitor_response_time_seconds Latest response time of the monitor
+# TYPE uptimerobot_monitor_response_time_seconds gauge
+uptimerobot_monitor_response_time_seconds{id="777000001",name="shop",type="http",url="https://shop.example.com/"} 0.25
+# HELP uptimerobot_monitor_status Status of the monitor (0 paused, 1 not checked yet, 2 up, 8 seems down, 9 down)
+# TYPE uptimerobot_monitor_status gauge
+uptimerobot_monitor_status{id="777000001",name="shop",type="http",url="https://shop.example.com/"} 2
+# HELP uptimerobot_monitor_uptime_ratio Uptime ratio (0-1) of the monitor in the period, all-time or the number of days
+# TYPE uptimerobot_monitor_uptime_ratio gauge
+uptimerobot_monitor_uptime_ratio{id="777000001",name="shop",period="30d",type="http",url="https://shop.example.com/"} 0.9990000000000001
+uptimerobot_monitor_uptime_ratio{id="777000001",name="shop",period="7d",type="http",url="https://shop.example.com/"} 1
+uptimerobot_monitor_uptime_ratio{id="777000001",name="shop",period="all",type="http",url="https://shop.example.com/"} 0.995
+# HELP uptimerobot_poll_success Whether the last poll of the UptimeRobot API succeeded
+# TYPE uptimerobot_poll_success gauge
+uptimerobot_poll_success 1
+# HELP uptimerobot_poll_timestamp_seconds Time of the last successful poll of the UptimeRobot API
+# TYPE uptimerobot_poll_timestamp_seconds gauge
+uptimerobot_poll_timestamp_seconds 1.7092908e+09
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Errorf("Unexpected metrics: %s", err)
+	}
+
+	// Failed polls keep the data of the last one
+	srv.Close()
+	if err := c.Poll(context.Background()); err == nil {
+		t.Fatalf("Expected the poll to fail")
+	}
+
+	if n := testutil.CollectAndCount(c, "uptimerobot_monitor_status"); n != 1 {
+		t.Errorf("Expected the monitor to be served from the cache, got %d metrics", n)
+	}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP uptimerobot_poll_success Whether the last poll of the UptimeRobot API succeeded
+# TYPE uptimerobot_poll_success gauge
+uptimerobot_poll_success 0
+`), "uptimerobot_poll_success"); err != nil {
+		t.Errorf("Unexpected metrics: %s", err)
+	}
+}
+
+// cancelLogger records the failure and stops the loop
+type cancelLogger struct {
+	cancel context.CancelFunc
+	msgs   []string
+}
+
+func (l *cancelLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {}
+
+func (l *cancelLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	l.msgs = append(l.msgs, msg)
+	l.cancel()
+}
+
+func TestRunLogsFailedPolls(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	ur := uptimerobot.New("u1234-wrongkey")
+	ur.BaseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &cancelLogger{cancel: cancel}
+	c := NewCollector(ur)
+	c.Logger = l
+
+	if err := c.Run(ctx); err != context.Canceled {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+	if len(l.msgs) != 1 || l.msgs[0] != "Polling the UptimeRobot API failed" {
+		t.Errorf("Expected the failed poll to be logged, got %q", l.msgs)
+	}
+}
@@ -0,0 +1,38 @@
+// Package poll contains the polling loop shared by the exporter and the
+// watcher.
+package poll
+
+import (
+	"context"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Logger returns the Logger of a polling loop: its own if set, otherwise the
+// one of the client or the standard logger if neither is set
+func Logger(l uptimerobot.Logger, ur *uptimerobot.UptimeRobot) uptimerobot.Logger {
+	if l != nil {
+		return l
+	}
+	if ur != nil && ur.Logger != nil {
+		return ur.Logger
+	}
+	return uptimerobot.StdLogger()
+}
+
+// Run calls fn every interval until the context is done. Failed calls are
+// logged as msg and retried with the next one.
+func Run(ctx context.Context, interval time.Duration, l uptimerobot.Logger, msg string, fn func(context.Context) error) error {
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			l.WarnContext(ctx, msg, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
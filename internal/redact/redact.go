@@ -0,0 +1,63 @@
+// Package redact replaces the secrets in the parameters and responses of API
+// calls. It is shared by the debug output of the client and the cassettes.
+package redact
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the values of secrets
+const Redacted = "REDACTED"
+
+// secrets are the parameters and response fields holding secrets
+var secrets = map[string]bool{
+	"api_key":       true,
+	"http_password": true,
+	"password":      true,
+}
+
+// fields and nonEmptyFields match the secret fields of a JSON document
+var (
+	fields         = fieldsRegexp("*")
+	nonEmptyFields = fieldsRegexp("+")
+)
+
+func fieldsRegexp(quantifier string) *regexp.Regexp {
+	names := []string{}
+	for name := range secrets {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	sort.Strings(names)
+	return regexp.MustCompile(`"(` + strings.Join(names, "|") + `)"(\s*:\s*)"(?:[^"\\]|\\.)` + quantifier + `"`)
+}
+
+// IsSecret reports whether the parameter or response field holds a secret
+func IsSecret(name string) bool {
+	return secrets[name]
+}
+
+// Values returns a copy of the parameters with the values of secret
+// parameters replaced
+func Values(params url.Values) url.Values {
+	safe := url.Values{}
+	for k, v := range params {
+		if secrets[k] {
+			v = []string{Redacted}
+		}
+		safe[k] = append([]string{}, v...)
+	}
+	return safe
+}
+
+// Body replaces the values of secret fields in a JSON document. With
+// keepEmpty empty values are left as they are.
+func Body(body []byte, keepEmpty bool) string {
+	re := fields
+	if keepEmpty {
+		re = nonEmptyFields
+	}
+	return re.ReplaceAllString(string(body), `"$1"$2"`+Redacted+`"`)
+}
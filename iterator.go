@@ -0,0 +1,187 @@
+package uptimerobot
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// pagination is the paging information returned by the list methods
+type pagination struct {
+	Offset flexInt `json:"offset"`
+	Limit  flexInt `json:"limit"`
+	Total  flexInt `json:"total"`
+}
+
+// pager holds the state shared by the iterators: it fetches one page at a time
+// and moves the offset forward until the last page has been seen
+type pager struct {
+	ctx     context.Context
+	params  *url.Values
+	fetch   func(ctx context.Context, params *url.Values) (pagination, error)
+	fetched bool
+	last    bool
+	total   int
+	err     error
+}
+
+func (p *pager) nextPage() bool {
+	if p.last || p.err != nil {
+		return false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	pg, err := p.fetch(p.ctx, p.params)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.fetched = true
+	p.total = int(pg.Total)
+
+	if pg.Limit <= 0 || pg.Offset+pg.Limit >= pg.Total {
+		p.last = true
+	} else {
+		p.params.Set("offset", strconv.Itoa(int(pg.Offset+pg.Limit)))
+	}
+	return true
+}
+
+// Total returns the number of items matching the request as reported by the
+// API. The first page is fetched if that did not happen yet.
+func (p *pager) Total() int {
+	if !p.fetched {
+		p.nextPage()
+	}
+	return p.total
+}
+
+// Err returns the error which stopped the iteration, if any
+func (p *pager) Err() error {
+	return p.err
+}
+
+// MonitorIterator fetches monitors lazily one page at a time. Call Next to
+// advance to the next monitor and Monitor to retrieve it; the iteration can be
+// stopped at any time by not calling Next anymore:
+//
+//	it := ur.IterateMonitors(nil)
+//	for it.Next() {
+//		m := it.Monitor()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type MonitorIterator struct {
+	pager
+	page []Monitor
+	cur  Monitor
+}
+
+// IterateMonitors returns an iterator over the monitors selected by the input
+// (see GetMonitors)
+func (u *UptimeRobot) IterateMonitors(in *GetMonitorsInput) *MonitorIterator {
+	return u.IterateMonitorsContext(context.Background(), in)
+}
+
+// IterateMonitorsContext is like IterateMonitors but uses the given context for
+// the requests
+func (u *UptimeRobot) IterateMonitorsContext(ctx context.Context, in *GetMonitorsInput) *MonitorIterator {
+	it := &MonitorIterator{}
+	params, err := u.getMonitorsParams(in)
+
+	it.pager = pager{
+		ctx:    ctx,
+		params: params,
+		err:    err,
+		fetch: func(ctx context.Context, params *url.Values) (pagination, error) {
+			page, pg, err := u.getMonitorsPage(ctx, params)
+			it.page = page
+			return pg, err
+		},
+	}
+	return it
+}
+
+// Next advances the iterator to the next monitor, fetching the next page if
+// required. It returns false when there are no more monitors or an error
+// occurred.
+func (it *MonitorIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.nextPage() {
+			return false
+		}
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Monitor returns the current monitor
+func (it *MonitorIterator) Monitor() Monitor {
+	return it.cur
+}
+
+// AlertContactIterator fetches alert contacts lazily one page at a time. It is
+// used like the MonitorIterator.
+type AlertContactIterator struct {
+	pager
+	page []AlertContact
+	cur  AlertContact
+}
+
+// IterateAlertContacts returns an iterator over the alert contacts with the
+// given IDs (or all of them if no IDs are given)
+func (u *UptimeRobot) IterateAlertContacts(contactIDs []int) *AlertContactIterator {
+	return u.IterateAlertContactsContext(context.Background(), contactIDs)
+}
+
+// IterateAlertContactsContext is like IterateAlertContacts but uses the given
+// context for the requests
+func (u *UptimeRobot) IterateAlertContactsContext(ctx context.Context, contactIDs []int) *AlertContactIterator {
+	it := &AlertContactIterator{}
+	params := &url.Values{
+		"limit":  []string{"50"},
+		"offset": []string{"0"},
+	}
+
+	if len(contactIDs) > 0 {
+		params.Set("alert_contacts", u.buildIntList(contactIDs))
+	}
+
+	it.pager = pager{
+		ctx:    ctx,
+		params: params,
+		fetch: func(ctx context.Context, params *url.Values) (pagination, error) {
+			page, pg, err := u.getAlertContactsPage(ctx, params)
+			it.page = page
+			return pg, err
+		},
+	}
+	return it
+}
+
+// Next advances the iterator to the next alert contact, fetching the next page
+// if required. It returns false when there are no more alert contacts or an
+// error occurred.
+func (it *AlertContactIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.nextPage() {
+			return false
+		}
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// AlertContact returns the current alert contact
+func (it *AlertContactIterator) AlertContact() AlertContact {
+	return it.cur
+}
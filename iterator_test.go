@@ -0,0 +1,88 @@
+package uptimerobot_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestMonitorIterator(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	srv.PageLimit = 2
+
+	for i := 0; i < 5; i++ {
+		srv.AddMonitor(uptimerobot.Monitor{
+			FriendlyName: fmt.Sprintf("monitor %d", i),
+			URL:          fmt.Sprintf("http://%d.example.com/", i),
+			Type:         uptimerobot.MonitorTypeHTTP,
+		})
+	}
+
+	transport := &countingTransport{}
+	ur := srv.Client()
+	ur.HTTPClient = &http.Client{Transport: transport}
+
+	it := ur.IterateMonitors(nil)
+	if it.Total() != 5 {
+		t.Errorf("Expected a total of 5 monitors, got %d", it.Total())
+	}
+
+	seen := 0
+	for it.Next() {
+		if m := it.Monitor(); m.FriendlyName != fmt.Sprintf("monitor %d", seen) {
+			t.Errorf("Unexpected monitor at position %d: %+v", seen, m)
+		}
+		seen++
+		if seen == 3 {
+			break
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if transport.requests != 2 {
+		t.Errorf("Expected 2 pages to be fetched when stopping early, got %d", transport.requests)
+	}
+}
+
+func TestAlertContactIterator(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	srv.PageLimit = 2
+
+	for i := 0; i < 3; i++ {
+		srv.AddAlertContact(uptimerobot.AlertContact{
+			Type:  uptimerobot.AlertContactTypeEMail,
+			Value: fmt.Sprintf("%d@example.com", i),
+		})
+	}
+
+	it := srv.Client().IterateAlertContacts(nil)
+	seen := 0
+	for it.Next() {
+		seen++
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if seen != 3 || it.Total() != 3 {
+		t.Errorf("Expected 3 alert contacts, saw %d with total %d", seen, it.Total())
+	}
+}
@@ -1,5 +1,10 @@
 package uptimerobot
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type LogType int
 
 const (
@@ -12,4 +17,46 @@ const (
 type Log struct {
 	Type     LogType         `json:"type,string"`
 	DateTime UptimeRobotDate `json:"datetime"`
+	// the time the monitor stayed in the state of the log entry
+	Duration time.Duration `json:"duration"`
+	// the cause of the state change (Example: Code "404", Detail "Not Found")
+	Reason LogReason `json:"reason"`
+	// the alert contacts notified about the state change (only returned if
+	// GetMonitorsInput.LogAlertContacts is set)
+	AlertContacts []AlertContact `json:"alertcontact"`
+}
+
+// LogReason explains why the state of a monitor changed
+type LogReason struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// logJSON is the representation of a log entry used by the API
+type logJSON struct {
+	Type     flexInt `json:"type"`
+	DateTime flexInt `json:"datetime"`
+	Duration flexInt `json:"duration"`
+	Reason   struct {
+		// the code is a number or a string depending on the monitor type
+		Code   json.RawMessage `json:"code"`
+		Detail string          `json:"detail"`
+	} `json:"reason"`
+	AlertContacts []alertContactJSON `json:"alert_contacts"`
+}
+
+func (jl logJSON) log() Log {
+	l := Log{
+		Type:     LogType(jl.Type),
+		DateTime: epoch(jl.DateTime),
+		Duration: time.Duration(jl.Duration) * time.Second,
+		Reason: LogReason{
+			Code:   rawString(jl.Reason.Code),
+			Detail: jl.Reason.Detail,
+		},
+	}
+	for _, jc := range jl.AlertContacts {
+		l.AlertContacts = append(l.AlertContacts, jc.alertContact())
+	}
+	return l
 }
@@ -0,0 +1,90 @@
+package uptimerobot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Logger receives the structured events of the client. The arguments are
+// alternating keys and values like in log/slog, so a *slog.Logger can be used
+// directly. Request IDs or other tags can be added with slog.Logger.With or
+// taken from the context by the handler.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// StdLogger returns a Logger writing the events to the standard logger of the
+// log package. The client uses it if FullDebug is set without a Logger.
+func StdLogger() Logger {
+	return stdLogger{}
+}
+
+type stdLogger struct{}
+
+func (stdLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
+	printEvent("DEBUG", msg, args)
+}
+
+func (stdLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	printEvent("WARN", msg, args)
+}
+
+func printEvent(level, msg string, args []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	log.Print(b.String())
+}
+
+// logger returns the logger for the events of the client (nil if logging is
+// disabled)
+func (u *UptimeRobot) logger() Logger {
+	if u.Logger != nil {
+		return u.Logger
+	}
+	if u.FullDebug {
+		return stdLogger{}
+	}
+	return nil
+}
+
+// logAttempt emits the event for a single attempt of an API call
+func (u *UptimeRobot) logAttempt(ctx context.Context, apiMethod string, params *url.Values, attempt int, duration time.Duration, err error) {
+	l := u.logger()
+	if l == nil {
+		return
+	}
+
+	args := []interface{}{
+		"method", apiMethod,
+		"params", redactParams(*params),
+		"attempt", attempt,
+		"duration", duration,
+	}
+	if offset := params.Get("offset"); offset != "" {
+		args = append(args, "offset", offset)
+	}
+
+	if err == nil {
+		l.DebugContext(ctx, "UptimeRobot API call", append(args, "stat", "ok")...)
+		return
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		args = append(args,
+			"stat", apiErr.Stat,
+			"status_code", apiErr.StatusCode,
+			"error_code", int(apiErr.Code),
+		)
+	}
+	l.WarnContext(ctx, "UptimeRobot API call failed", append(args, "error", err.Error())...)
+}
@@ -0,0 +1,60 @@
+package uptimerobot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getMonitors") {
+			fmt.Fprint(w, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":0},"monitors":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"stat":"fail","error":{"type":"not_found","parameter_name":"id","passed_value":"42","message":"Monitor not found."}}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	ur := New("u1234-topsecret")
+	ur.BaseURL = srv.URL
+	ur.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})).With("request_id", "abc")
+
+	if _, err := ur.GetMonitors(nil); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if err := ur.DeleteMonitor(42); err == nil {
+		t.Fatalf("Test should have errored.")
+	}
+
+	if strings.Contains(buf.String(), "topsecret") {
+		t.Errorf("Log contains the API-key: %s", buf.String())
+	}
+
+	events := []map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		e := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("Test errored: %s", err)
+		}
+		events = append(events, e)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d: %s", len(events), buf.String())
+	}
+
+	ok, failed := events[0], events[1]
+	if ok["level"] != "DEBUG" || ok["method"] != "getMonitors" || ok["offset"] != "0" || ok["stat"] != "ok" || ok["request_id"] != "abc" {
+		t.Errorf("Unexpected event for the successful call: %v", ok)
+	}
+	if failed["level"] != "WARN" || failed["method"] != "deleteMonitor" || failed["error_code"] != float64(ErrorMonitorIDNoExists) || failed["stat"] != "fail" {
+		t.Errorf("Unexpected event for the failed call: %v", failed)
+	}
+}
@@ -0,0 +1,83 @@
+// Package maintenance enforces maintenance windows on the client side for
+// accounts whose plan does not support them: the monitors attached to a window
+// are paused when it starts and resumed when it ends.
+//
+//	s := maintenance.NewScheduler(ur, windows)
+//	s.Location, _ = time.LoadLocation("Europe/Berlin")
+//	err := s.Run(ctx)
+//
+// The windows can be fetched with GetMaintenanceWindows or defined locally.
+// One-time windows start at the given instant, the start times of recurring
+// windows are wall clock times evaluated in the Location of the scheduler.
+package maintenance
+
+import (
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Occurrence is a single period during which a maintenance window is in effect
+type Occurrence struct {
+	Window uptimerobot.MaintenanceWindow
+	Start  time.Time
+	End    time.Time
+}
+
+// Next returns the occurrence of the window which is in effect at t or, if
+// there is none, the next one starting after t. It returns false for paused
+// windows and windows which will not be in effect anymore.
+func Next(w uptimerobot.MaintenanceWindow, t time.Time, loc *time.Location) (Occurrence, bool) {
+	if w.Status == uptimerobot.MaintenanceWindowStatusPaused || w.Duration <= 0 {
+		return Occurrence{}, false
+	}
+
+	if w.Type == uptimerobot.MaintenanceWindowTypeOnce {
+		if end := w.Start.Add(w.Duration); end.After(t) {
+			return Occurrence{Window: w, Start: w.Start, End: end}, true
+		}
+		return Occurrence{}, false
+	}
+
+	// Look back far enough to find an occurrence started on a previous day
+	// which is still in effect and ahead for the sparsest monthly windows
+	hour, minute := w.Start.Hour(), w.Start.Minute()
+	t = t.In(loc)
+	back := int(w.Duration/(24*time.Hour)) + 1
+	for i := -back; i <= 62; i++ {
+		start := time.Date(t.Year(), t.Month(), t.Day()+i, hour, minute, 0, 0, loc)
+		if !occursOn(w, start) {
+			continue
+		}
+		if end := start.Add(w.Duration); end.After(t) {
+			return Occurrence{Window: w, Start: start, End: end}, true
+		}
+	}
+	return Occurrence{}, false
+}
+
+func occursOn(w uptimerobot.MaintenanceWindow, day time.Time) bool {
+	switch w.Type {
+	case uptimerobot.MaintenanceWindowTypeDaily:
+		return true
+	case uptimerobot.MaintenanceWindowTypeWeekly:
+		// The API counts the weekdays from Monday (1) to Sunday (7)
+		weekday := int(day.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return contains(w.Days, weekday)
+	case uptimerobot.MaintenanceWindowTypeMonthly:
+		return contains(w.Days, day.Day())
+	}
+	return false
+}
+
+func contains(list []int, i int) bool {
+	for _, v := range list {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
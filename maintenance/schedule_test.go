@@ -0,0 +1,60 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone data not available: %s", err)
+	}
+
+	clock := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatalf("Test errored: %s", err)
+		}
+		return v
+	}
+	at := func(s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, berlin)
+		if err != nil {
+			t.Fatalf("Test errored: %s", err)
+		}
+		return v
+	}
+
+	active := uptimerobot.MaintenanceWindowStatusActive
+	tests := []struct {
+		name   string
+		window uptimerobot.MaintenanceWindow
+		now    string
+		start  string
+		ok     bool
+	}{
+		{"once ahead", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeOnce, Start: at("2017-03-01 22:00"), Duration: time.Hour, Status: active}, "2017-03-01 12:00", "2017-03-01 22:00", true},
+		{"once in effect", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeOnce, Start: at("2017-03-01 22:00"), Duration: time.Hour, Status: active}, "2017-03-01 22:30", "2017-03-01 22:00", true},
+		{"once over", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeOnce, Start: at("2017-03-01 22:00"), Duration: time.Hour, Status: active}, "2017-03-01 23:00", "", false},
+		{"daily over midnight", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeDaily, Start: clock("0000-01-01 23:30"), Duration: time.Hour, Status: active}, "2017-03-02 00:15", "2017-03-01 23:30", true},
+		{"daily tomorrow", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeDaily, Start: clock("0000-01-01 02:00"), Duration: time.Hour, Status: active}, "2017-03-01 03:00", "2017-03-02 02:00", true},
+		{"weekly sunday", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeWeekly, Days: []int{7}, Start: clock("0000-01-01 04:00"), Duration: time.Hour, Status: active}, "2017-03-01 12:00", "2017-03-05 04:00", true},
+		{"weekly across DST", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeWeekly, Days: []int{1}, Start: clock("0000-01-01 04:00"), Duration: time.Hour, Status: active}, "2017-03-25 12:00", "2017-03-27 04:00", true},
+		{"monthly skips short months", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeMonthly, Days: []int{31}, Start: clock("0000-01-01 01:00"), Duration: time.Hour, Status: active}, "2017-02-01 00:00", "2017-03-31 01:00", true},
+		{"paused", uptimerobot.MaintenanceWindow{Type: uptimerobot.MaintenanceWindowTypeDaily, Start: clock("0000-01-01 02:00"), Duration: time.Hour}, "2017-03-01 00:00", "", false},
+	}
+
+	for _, tt := range tests {
+		o, ok := Next(tt.window, at(tt.now), berlin)
+		if ok != tt.ok {
+			t.Errorf("%s: expected ok to be %v, got %v", tt.name, tt.ok, ok)
+			continue
+		}
+		if ok && (!o.Start.Equal(at(tt.start)) || !o.End.Equal(at(tt.start).Add(tt.window.Duration))) {
+			t.Errorf("%s: expected the occurrence to start at %s, got %s - %s", tt.name, tt.start, o.Start, o.End)
+		}
+	}
+}
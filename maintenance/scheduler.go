@@ -0,0 +1,190 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// maxWait limits how long Run sleeps so changes of the system clock are
+// picked up eventually
+const maxWait = time.Hour
+
+// Scheduler pauses the monitors of the maintenance windows in effect and
+// resumes them once the windows are over. Monitors which were paused already
+// when a window started are left alone.
+type Scheduler struct {
+	// the windows to enforce, paused windows are ignored
+	Windows []uptimerobot.MaintenanceWindow
+	// the time zone the windows are evaluated in (default: time.Local)
+	Location *time.Location
+	// the time to wait before retrying a monitor which failed to be paused or
+	// resumed (default: one minute)
+	RetryInterval time.Duration
+	// Logger receives the failures (default: the Logger of the client, the
+	// standard logger if neither is set)
+	Logger uptimerobot.Logger
+
+	ur  *uptimerobot.UptimeRobot
+	now func() time.Time
+
+	mu sync.Mutex
+	// the monitors of the windows in effect, true if the scheduler paused them
+	handled map[int]bool
+}
+
+// NewScheduler creates a scheduler enforcing the given windows
+func NewScheduler(ur *uptimerobot.UptimeRobot, windows []uptimerobot.MaintenanceWindow) *Scheduler {
+	return &Scheduler{
+		Windows:       windows,
+		Location:      time.Local,
+		RetryInterval: time.Minute,
+		ur:            ur,
+		now:           time.Now,
+		handled:       map[int]bool{},
+	}
+}
+
+// Sync pauses the monitors of the windows in effect at now and resumes the
+// monitors paused by an earlier call whose windows are over. It returns the
+// time at which the next window starts or ends (zero if there is none), or
+// the time to retry at if a monitor failed. Failures of single monitors are
+// logged and do not keep the others from being synced, deleted monitors are
+// skipped. Only the error of the context is returned.
+func (s *Scheduler) Sync(ctx context.Context, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var next time.Time
+	active := map[int]bool{}
+	for _, w := range s.Windows {
+		o, ok := Next(w, now, loc)
+		if !ok {
+			continue
+		}
+
+		boundary := o.Start
+		if !o.Start.After(now) {
+			for _, id := range w.Monitors {
+				active[id] = true
+			}
+			boundary = o.End
+		}
+
+		if next.IsZero() || boundary.Before(next) {
+			next = boundary
+		}
+	}
+
+	retry := false
+	for _, id := range sortedIDs(active) {
+		if _, ok := s.handled[id]; ok {
+			continue
+		}
+
+		changed, err := s.ur.PauseMonitorContext(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return next, ctx.Err()
+			}
+			s.logger().WarnContext(ctx, "Unable to pause monitor", "monitor", id, "error", err)
+			if errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+				// Nothing to resume at the end of the window
+				s.handled[id] = false
+			} else {
+				retry = true
+			}
+			continue
+		}
+		s.handled[id] = changed
+	}
+
+	for _, id := range sortedIDs(s.handled) {
+		if active[id] {
+			continue
+		}
+
+		if s.handled[id] {
+			if _, err := s.ur.ResumeMonitorContext(ctx, id); err != nil {
+				if ctx.Err() != nil {
+					return next, ctx.Err()
+				}
+				s.logger().WarnContext(ctx, "Unable to resume monitor", "monitor", id, "error", err)
+				if !errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+					retry = true
+					continue
+				}
+			}
+		}
+		delete(s.handled, id)
+	}
+
+	if retry {
+		interval := s.RetryInterval
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		if at := now.Add(interval); next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	return next, nil
+}
+
+// Run calls Sync whenever a window starts or ends until the context is done
+// or no window will be in effect anymore. Monitors which failed are retried
+// after the RetryInterval. Monitors stay paused when Run returns during a
+// window.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		now := s.now()
+		next, err := s.Sync(ctx, now)
+		if err != nil {
+			return err
+		}
+		if next.IsZero() {
+			return nil
+		}
+
+		wait := next.Sub(now)
+		if wait > maxWait {
+			wait = maxWait
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Scheduler) logger() uptimerobot.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	if s.ur != nil && s.ur.Logger != nil {
+		return s.ur.Logger
+	}
+	return uptimerobot.StdLogger()
+}
+
+func sortedIDs(m map[int]bool) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
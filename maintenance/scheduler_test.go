@@ -0,0 +1,133 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestSchedulerSync(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ctx := context.Background()
+
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, Status: uptimerobot.MonitorStatusUp})
+	db := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "db", URL: "db.example.com", Type: uptimerobot.MonitorTypePing, Status: uptimerobot.MonitorStatusPaused})
+
+	start := time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC)
+	s := NewScheduler(srv.Client(), []uptimerobot.MaintenanceWindow{{
+		Type:     uptimerobot.MaintenanceWindowTypeDaily,
+		Start:    start,
+		Duration: 30 * time.Minute,
+		Status:   uptimerobot.MaintenanceWindowStatusActive,
+		Monitors: []int{web.ID, db.ID},
+	}})
+	s.Location = time.UTC
+
+	status := func() map[int]uptimerobot.MonitorStatus {
+		out := map[int]uptimerobot.MonitorStatus{}
+		for _, m := range srv.Monitors() {
+			out[m.ID] = m.Status
+		}
+		return out
+	}
+
+	next, err := s.Sync(ctx, time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if want := time.Date(2017, 3, 1, 22, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected the next sync at %s, got %s", want, next)
+	}
+	if st := status(); st[web.ID] != uptimerobot.MonitorStatusUp {
+		t.Errorf("Monitor was paused before the window started: %v", st)
+	}
+
+	next, err = s.Sync(ctx, time.Date(2017, 3, 1, 22, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if want := time.Date(2017, 3, 1, 22, 30, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected the next sync at %s, got %s", want, next)
+	}
+	if st := status(); st[web.ID] != uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor was not paused during the window: %v", st)
+	}
+
+	if _, err := s.Sync(ctx, time.Date(2017, 3, 1, 22, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	st := status()
+	if st[web.ID] == uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor was not resumed after the window: %v", st)
+	}
+	if st[db.ID] != uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor paused before the window was resumed: %v", st)
+	}
+}
+
+func TestSchedulerRunStopsWithoutWindows(t *testing.T) {
+	s := NewScheduler(nil, nil)
+	if err := s.Run(context.Background()); err != nil {
+		t.Errorf("Test errored: %s", err)
+	}
+}
+
+// warnings collects the failures logged by the scheduler
+type warnings []string
+
+func (w *warnings) DebugContext(ctx context.Context, msg string, args ...interface{}) {}
+
+func (w *warnings) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	*w = append(*w, fmt.Sprintln(append([]interface{}{msg}, args...)...))
+}
+
+func TestSchedulerSkipsFailedMonitors(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ctx := context.Background()
+
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, Status: uptimerobot.MonitorStatusUp})
+
+	s := NewScheduler(srv.Client(), []uptimerobot.MaintenanceWindow{{
+		Type:     uptimerobot.MaintenanceWindowTypeDaily,
+		Start:    time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC),
+		Duration: 30 * time.Minute,
+		Status:   uptimerobot.MaintenanceWindowStatusActive,
+		// 42 was deleted from the account
+		Monitors: []int{42, web.ID},
+	}})
+	s.Location = time.UTC
+	logged := &warnings{}
+	s.Logger = logged
+
+	next, err := s.Sync(ctx, time.Date(2017, 3, 1, 22, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if want := time.Date(2017, 3, 1, 22, 30, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected the next sync at %s, got %s", want, next)
+	}
+	if m := srv.Monitors()[0]; m.Status != uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor was not paused during the window: %+v", m)
+	}
+	if len(*logged) != 1 || !strings.Contains((*logged)[0], "monitor 42") {
+		t.Errorf("Expected the failure of monitor 42 to be logged, got %q", *logged)
+	}
+
+	// The deleted monitor is not tried again
+	if _, err := s.Sync(ctx, time.Date(2017, 3, 1, 22, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if m := srv.Monitors()[0]; m.Status == uptimerobot.MonitorStatusPaused {
+		t.Errorf("Monitor was not resumed after the window: %+v", m)
+	}
+	if len(*logged) != 1 {
+		t.Errorf("Expected no further failures, got %q", *logged)
+	}
+}
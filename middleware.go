@@ -0,0 +1,171 @@
+package uptimerobot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Call is a single call of an API method passing through the middlewares of
+// the client
+type Call struct {
+	// the API method (Example: "getMonitors")
+	Method string
+	// the parameters sent to the API including the API-key, changes made by
+	// a middleware before calling the next handler are sent
+	Params url.Values
+
+	// the outcome of the call, set once the next handler returned
+	Stat      string
+	ErrorCode APIError
+	// the time spent in the API including retries and the number of attempts
+	Duration time.Duration
+	Attempts int
+	// the raw JSON response the result is decoded from. A middleware which
+	// does not call the next handler can set it to answer the call itself.
+	Response []byte
+}
+
+// SafeParams returns a copy of the parameters with secrets like the API-key
+// and passwords redacted
+func (c *Call) SafeParams() url.Values {
+	return redactValues(c.Params)
+}
+
+// Handler performs an API call
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps the handler of the API calls. It can inspect or modify the
+// call before and after calling next, skip next to short-circuit the call or
+// replace the returned error:
+//
+//	ur.Middlewares = append(ur.Middlewares, func(next uptimerobot.Handler) uptimerobot.Handler {
+//		return func(ctx context.Context, call *uptimerobot.Call) error {
+//			err := next(ctx, call)
+//			log.Printf("%s took %s", call.Method, call.Duration)
+//			return err
+//		}
+//	})
+type Middleware func(next Handler) Handler
+
+// TimingMiddleware reports the method, duration and error of every call to
+// observe, for example to feed metrics
+func TimingMiddleware(observe func(method string, d time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			start := time.Now()
+			err := next(ctx, call)
+			observe(call.Method, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// Recorder keeps a copy of every call passing its middleware, for auditing
+// or assertions in tests. The secrets in the parameters are redacted.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Middleware returns the middleware recording the calls
+func (r *Recorder) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+
+			recorded := *call
+			recorded.Params = call.SafeParams()
+			recorded.Response = append([]byte{}, call.Response...)
+
+			r.mu.Lock()
+			r.calls = append(r.calls, recorded)
+			r.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// Calls returns the calls recorded so far
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call{}, r.calls...)
+}
+
+// dryRunResults describes the synthetic result of the mutating methods per
+// resource: the key of the result in the response and the status of new items
+var dryRunResults = map[string]struct {
+	key    string
+	status int
+}{
+	"Monitor":      {"monitor", int(MonitorStatusNotCheckedYet)},
+	"AlertContact": {"alertcontact", int(AlertContactStatusNotActivated)},
+	"MWindow":      {"mwindow", int(MaintenanceWindowStatusActive)},
+	"PSP":          {"psp", int(PublicStatusPageStatusActive)},
+}
+
+// isMutation reports whether the API method changes the account
+func isMutation(apiMethod string) bool {
+	return !strings.HasPrefix(apiMethod, "get")
+}
+
+// DryRunMiddleware keeps calls of methods changing the account from reaching
+// the API: they are passed to record and answered with a synthetic success.
+// Edits keep the ID of the edited item, created items get the ID 0. Calls
+// with parameters the API would reject without looking at the account, like a
+// missing ID or an unknown type, fail with the *Error the API would return and
+// are not recorded. Calls reading from the API pass through.
+func DryRunMiddleware(record func(call Call)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			if !isMutation(call.Method) {
+				return next(ctx, call)
+			}
+
+			if typ, param := checkParams(call.Method, call.Params); typ != "" {
+				call.Stat = "fail"
+				call.ErrorCode = errorCode(call.Method, typ, param)
+				return &Error{
+					Method:        call.Method,
+					StatusCode:    http.StatusOK,
+					Stat:          call.Stat,
+					Code:          call.ErrorCode,
+					Type:          typ,
+					ParameterName: param,
+					PassedValue:   call.SafeParams().Get(param),
+				}
+			}
+
+			id, _ := strconv.Atoi(call.Params.Get("id"))
+			result := map[string]interface{}{"stat": "ok"}
+			if r, ok := dryRunResults[apiResource(call.Method)]; ok {
+				item := map[string]int{"id": id}
+				if strings.HasPrefix(call.Method, "new") {
+					item["status"] = r.status
+				}
+				result[r.key] = item
+			}
+
+			res, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+
+			call.Stat = "ok"
+			call.Response = res
+			if record != nil {
+				recorded := *call
+				recorded.Params = call.SafeParams()
+				record(recorded)
+			}
+			return nil
+		}
+	}
+}
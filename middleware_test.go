@@ -0,0 +1,100 @@
+package uptimerobot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestMiddlewares(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+
+	timings := map[string]int{}
+	recorder := &uptimerobot.Recorder{}
+	injected := errors.New("injected")
+
+	ur := srv.Client()
+	ur.Middlewares = []uptimerobot.Middleware{
+		uptimerobot.TimingMiddleware(func(method string, d time.Duration, err error) {
+			timings[method]++
+		}),
+		recorder.Middleware(),
+		// Fault injection short-circuiting resets
+		func(next uptimerobot.Handler) uptimerobot.Handler {
+			return func(ctx context.Context, call *uptimerobot.Call) error {
+				if call.Method == "resetMonitor" {
+					return injected
+				}
+				return next(ctx, call)
+			}
+		},
+	}
+
+	mons, err := ur.GetMonitors(nil)
+	if err != nil || len(mons) != 1 {
+		t.Fatalf("Unexpected monitors %+v (%v)", mons, err)
+	}
+
+	if err := ur.ResetMonitor(mons[0].ID); err != injected {
+		t.Errorf("Expected the injected error, got: %v", err)
+	}
+
+	if err := ur.DeleteMonitor(42); !errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+		t.Errorf("Expected ErrorMonitorIDNoExists, got: %v", err)
+	}
+
+	if timings["getMonitors"] != 1 || timings["resetMonitor"] != 1 || timings["deleteMonitor"] != 1 {
+		t.Errorf("Unexpected timings: %v", timings)
+	}
+
+	calls := recorder.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 recorded calls, got %+v", calls)
+	}
+
+	if c := calls[0]; c.Method != "getMonitors" || c.Stat != "ok" || c.Attempts != 1 || len(c.Response) == 0 || c.Params.Get("api_key") == "u1234-testkey" {
+		t.Errorf("Unexpected call: %+v", c)
+	}
+
+	if c := calls[2]; c.Method != "deleteMonitor" || c.Stat != "fail" || c.ErrorCode != uptimerobot.ErrorMonitorIDNoExists || c.Params.Get("id") != "42" {
+		t.Errorf("Unexpected call: %+v", c)
+	}
+}
+
+func TestDryRunMiddleware(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+
+	recorded := []uptimerobot.Call{}
+	ur := srv.Client()
+	ur.Middlewares = []uptimerobot.Middleware{uptimerobot.DryRunMiddleware(func(call uptimerobot.Call) {
+		recorded = append(recorded, call)
+	})}
+
+	m, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if m.ID != 0 || m.Status != uptimerobot.MonitorStatusNotCheckedYet {
+		t.Errorf("Unexpected synthetic monitor: %+v", m)
+	}
+
+	if err := ur.DeleteMonitor(web.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if mons := srv.Monitors(); len(mons) != 1 || mons[0].ID != web.ID {
+		t.Errorf("Expected the account to be unchanged, got %+v", mons)
+	}
+
+	if len(recorded) != 2 || recorded[0].Method != "newMonitor" || recorded[0].Params.Get("friendly_name") != "api" || recorded[1].Method != "deleteMonitor" {
+		t.Errorf("Unexpected recorded calls: %+v", recorded)
+	}
+}
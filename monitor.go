@@ -1,6 +1,9 @@
 package uptimerobot
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -50,23 +53,34 @@ const (
 )
 
 type Monitor struct {
-	ID                 int                `json:"id,string"`
-	FriendlyName       string             `json:"friendlyname"`
-	URL                string             `json:"url"`
-	Type               MonitorType        `json:"type,string"`
-	Subtype            MonitorSubtype     `json:"subtype,string"`
-	KeywordType        MonitorKeywordType `json:"keywordtype,string"`
-	KeywordValue       string             `json:"keywordvalue"`
-	HTTPUsername       string             `json:"httpusername"`
-	HTTPPassword       string             `json:"httppassword"`
-	Port               int                `json:"port,string"`
-	Interval           int                `json:"interval,string"`
-	Status             MonitorStatus      `json:"status,string"`
-	AlltimeUptimeRatio float64            `json:"alltimeuptimeratio,string"`
-	CustomUptimeRatio  float64            `json:"customuptimeratio,string"`
-	AlertContacts      []AlertContact     `json:"alertcontact"`
-	Logs               []Log              `json:"log"`
-	ResponseTimes      []ResponseTime     `json:"responsetime"`
+	ID           int                `json:"id,string"`
+	FriendlyName string             `json:"friendlyname"`
+	URL          string             `json:"url"`
+	Type         MonitorType        `json:"type,string"`
+	Subtype      MonitorSubtype     `json:"subtype,string"`
+	KeywordType  MonitorKeywordType `json:"keywordtype,string"`
+	KeywordValue string             `json:"keywordvalue"`
+	HTTPUsername string             `json:"httpusername"`
+	HTTPPassword string             `json:"httppassword"`
+	Port         int                `json:"port,string"`
+	// the check interval in seconds
+	Interval           int           `json:"interval,string"`
+	Status             MonitorStatus `json:"status,string"`
+	AlltimeUptimeRatio float64       `json:"alltimeuptimeratio,string"`
+	// the uptime ratio of the first period of GetMonitorsInput.CustomUptimeRatio
+	CustomUptimeRatio float64 `json:"customuptimeratio,string"`
+	// the uptime ratios of all periods of GetMonitorsInput.CustomUptimeRatio
+	CustomUptimeRatios []float64      `json:"customuptimeratios"`
+	AlertContacts      []AlertContact `json:"alertcontact"`
+	Logs               []Log          `json:"log"`
+	ResponseTimes      []ResponseTime `json:"responsetime"`
+	// the certificate of HTTPS monitors (only returned if
+	// GetMonitorsInput.SSL is set)
+	SSL *SSLInfo `json:"ssl"`
+	// optional (headers sent with the requests of HTTP and keyword monitors)
+	CustomHTTPHeaders map[string]string `json:"customhttpheaders"`
+	// optional (the IDs of the maintenance windows of the monitor)
+	MaintenanceWindows []int `json:"mwindows"`
 }
 
 type ResponseTime struct {
@@ -74,6 +88,121 @@ type ResponseTime struct {
 	Value    int             `json:"value,string"`
 }
 
+// SSLInfo describes the certificate of a monitored site
+type SSLInfo struct {
+	Brand   string
+	Product string
+	Expires time.Time
+	// whether certificate errors are ignored and expiry notifications are
+	// disabled for the monitor
+	IgnoreErrors         bool
+	DisableNotifications bool
+}
+
+// monitorJSON is the representation of a monitor used by the API
+type monitorJSON struct {
+	ID                 flexInt            `json:"id"`
+	FriendlyName       string             `json:"friendly_name"`
+	URL                string             `json:"url"`
+	Type               flexInt            `json:"type"`
+	SubType            flexInt            `json:"sub_type"`
+	KeywordType        flexInt            `json:"keyword_type"`
+	KeywordValue       string             `json:"keyword_value"`
+	HTTPUsername       string             `json:"http_username"`
+	HTTPPassword       string             `json:"http_password"`
+	Port               flexInt            `json:"port"`
+	Interval           flexInt            `json:"interval"`
+	Status             flexInt            `json:"status"`
+	AllTimeUptimeRatio flexFloat          `json:"all_time_uptime_ratio"`
+	CustomUptimeRatio  json.RawMessage    `json:"custom_uptime_ratio"`
+	AlertContacts      []alertContactJSON `json:"alert_contacts"`
+	Logs               []logJSON          `json:"logs"`
+	ResponseTimes      []struct {
+		DateTime flexInt `json:"datetime"`
+		Value    flexInt `json:"value"`
+	} `json:"response_times"`
+	SSL *struct {
+		Brand                string  `json:"brand"`
+		Product              string  `json:"product"`
+		Expires              flexInt `json:"expires"`
+		IgnoreErrors         flexInt `json:"ignore_errors"`
+		DisableNotifications flexInt `json:"disable_notifications"`
+	} `json:"ssl"`
+	// an object or an empty array if the monitor has no headers
+	CustomHTTPHeaders  json.RawMessage `json:"custom_http_headers"`
+	MaintenanceWindows []struct {
+		ID flexInt `json:"id"`
+	} `json:"mwindows"`
+}
+
+func (jm monitorJSON) monitor() Monitor {
+	m := Monitor{
+		ID:                 int(jm.ID),
+		FriendlyName:       jm.FriendlyName,
+		URL:                jm.URL,
+		Type:               MonitorType(jm.Type),
+		Subtype:            MonitorSubtype(jm.SubType),
+		KeywordType:        MonitorKeywordType(jm.KeywordType),
+		KeywordValue:       jm.KeywordValue,
+		HTTPUsername:       jm.HTTPUsername,
+		HTTPPassword:       jm.HTTPPassword,
+		Port:               int(jm.Port),
+		Interval:           int(jm.Interval),
+		Status:             MonitorStatus(jm.Status),
+		AlltimeUptimeRatio: float64(jm.AllTimeUptimeRatio),
+	}
+
+	// The API leaves the subtype and keyword type empty for monitors which
+	// do not use them
+	if m.Subtype == 0 {
+		m.Subtype = MonitorSubtypeHTTP
+	}
+	if m.KeywordType == 0 {
+		m.KeywordType = MonitorKeywordTypeExists
+	}
+
+	if ratios := rawString(jm.CustomUptimeRatio); ratios != "" && ratios != "null" {
+		m.CustomUptimeRatios = parseFloatList(ratios)
+		if len(m.CustomUptimeRatios) > 0 {
+			m.CustomUptimeRatio = m.CustomUptimeRatios[0]
+		}
+	}
+
+	for _, jc := range jm.AlertContacts {
+		m.AlertContacts = append(m.AlertContacts, jc.alertContact())
+	}
+	for _, jl := range jm.Logs {
+		m.Logs = append(m.Logs, jl.log())
+	}
+	for _, jr := range jm.ResponseTimes {
+		m.ResponseTimes = append(m.ResponseTimes, ResponseTime{
+			DateTime: epoch(jr.DateTime),
+			Value:    int(jr.Value),
+		})
+	}
+
+	if jm.SSL != nil {
+		m.SSL = &SSLInfo{
+			Brand:                jm.SSL.Brand,
+			Product:              jm.SSL.Product,
+			Expires:              time.Time(epoch(jm.SSL.Expires)),
+			IgnoreErrors:         jm.SSL.IgnoreErrors != 0,
+			DisableNotifications: jm.SSL.DisableNotifications != 0,
+		}
+	}
+
+	headers := map[string]string{}
+	if json.Unmarshal(jm.CustomHTTPHeaders, &headers) == nil && len(headers) > 0 {
+		m.CustomHTTPHeaders = headers
+	}
+
+	for _, jw := range jm.MaintenanceWindows {
+		m.MaintenanceWindows = append(m.MaintenanceWindows, int(jw.ID))
+	}
+
+	return m
+}
+
 // GetMonitorsInput is the Input type for the GetMonitors function. All parameters
 // are optional and does not need to be set. The default values will give a list
 // of all monitors
@@ -92,8 +221,18 @@ type GetMonitorsInput struct {
 	CustomUptimeRatio []int
 	// optional (defines if the logs of each monitor will be returned.)
 	Logs bool
+	// optional (the number of the newest logs to be returned, all logs are
+	// returned if not set)
+	LogsLimit int
+	// optional (returns only the logs of the given period, both dates must be
+	// set)
+	LogsStartDate *time.Time
+	LogsEndDate   *time.Time
 	// optional (defines if the response time data of each monitor will be returned.)
 	ResponseTimes bool
+	// optional (the number of the newest response times to be returned, all
+	// response times of the period are returned if not set)
+	ResponseTimesLimit int
 	// optional (by default, response time value of each check is returned. The
 	// API can return average values in given minutes. Default is 0. For ex: the
 	// Uptime Robot dashboard displays the data averaged/grouped in 30 minutes)
@@ -120,12 +259,42 @@ type GetMonitorsInput struct {
 	// optional (a keyword of your choice to search within Monitor.URL and
 	// Monitor.FriendlyName and get filtered results)
 	Search string
+	// optional (defines if the SSL certificate info of each monitor will be
+	// returned.)
+	SSL bool
+	// optional (defines if the custom HTTP headers of each monitor will be
+	// returned.)
+	CustomHTTPHeaders bool
+	// optional (defines if the maintenance windows of each monitor will be
+	// returned.)
+	MaintenanceWindows bool
 }
 
 // GetMonitors is a Swiss-Army knife type of a method for getting any information
 // on monitors.
 func (u *UptimeRobot) GetMonitors(in *GetMonitorsInput) ([]Monitor, error) {
-	params := url.Values{}
+	return u.GetMonitorsContext(context.Background(), in)
+}
+
+// GetMonitorsContext is like GetMonitors but uses the given context for the
+// requests. The context is also checked between the pages of the result.
+func (u *UptimeRobot) GetMonitorsContext(ctx context.Context, in *GetMonitorsInput) ([]Monitor, error) {
+	it := u.IterateMonitorsContext(ctx, in)
+
+	result := []Monitor{}
+	for it.Next() {
+		result = append(result, it.Monitor())
+	}
+
+	if err := it.Err(); err != nil {
+		return []Monitor{}, err
+	}
+
+	return result, nil
+}
+
+func (u *UptimeRobot) getMonitorsParams(in *GetMonitorsInput) (*url.Values, error) {
+	params := &url.Values{}
 
 	if in == nil {
 		in = &GetMonitorsInput{}
@@ -144,28 +313,44 @@ func (u *UptimeRobot) GetMonitors(in *GetMonitorsInput) ([]Monitor, error) {
 	}
 
 	if len(in.CustomUptimeRatio) > 0 {
-		params.Set("customUptimeRatio", u.buildIntList(in.CustomUptimeRatio))
+		params.Set("custom_uptime_ratios", u.buildIntList(in.CustomUptimeRatio))
 	}
 
 	params.Set("logs", u.bool2str(in.Logs))
-	params.Set("responseTimes", u.bool2str(in.ResponseTimes))
+
+	if in.LogsLimit > 0 {
+		params.Set("logs_limit", strconv.FormatInt(int64(in.LogsLimit), 10))
+	}
+
+	if in.LogsStartDate != nil && in.LogsEndDate != nil {
+		params.Set("logs_start_date", strconv.FormatInt(in.LogsStartDate.Unix(), 10))
+		params.Set("logs_end_date", strconv.FormatInt(in.LogsEndDate.Unix(), 10))
+	}
+	params.Set("response_times", u.bool2str(in.ResponseTimes))
+
+	if in.ResponseTimesLimit > 0 {
+		params.Set("response_times_limit", strconv.FormatInt(int64(in.ResponseTimesLimit), 10))
+	}
 
 	if in.ResponseTimeAverage > 0 {
-		params.Set("responseTimeAverage", strconv.FormatInt(int64(in.ResponseTimeAverage), 10))
+		params.Set("response_times_average", strconv.FormatInt(int64(in.ResponseTimeAverage), 10))
 	}
 
 	if in.ResponseTimeStartDate != nil && in.ResponseTimeEndDate != nil {
 		if len(in.Monitors) != 1 || in.ResponseTimeEndDate.Sub(*in.ResponseTimeStartDate) > 7*24*time.Hour {
-			return []Monitor{}, fmt.Errorf("Logic error. Please check documentation for StartDate & EndDate")
+			return nil, fmt.Errorf("Logic error. Please check documentation for StartDate & EndDate")
 		}
 
-		params.Set("responseTimesStartDate", in.ResponseTimeStartDate.Format("2006-01-02"))
-		params.Set("responseTimesEndDate", in.ResponseTimeEndDate.Format("2006-01-02"))
+		params.Set("response_times_start_date", strconv.FormatInt(in.ResponseTimeStartDate.Unix(), 10))
+		params.Set("response_times_end_date", strconv.FormatInt(in.ResponseTimeEndDate.Unix(), 10))
 	}
 
-	params.Set("alertContacts", u.bool2str(in.LogAlertContacts))
-	params.Set("showMonitorAlertContacts", u.bool2str(in.ShowMonitorAlertContacts))
-	params.Set("showTimezone", u.bool2str(in.ShowTimezone))
+	params.Set("alert_contacts", u.bool2str(in.LogAlertContacts))
+	params.Set("show_monitor_alert_contacts", u.bool2str(in.ShowMonitorAlertContacts))
+	params.Set("timezone", u.bool2str(in.ShowTimezone))
+	params.Set("ssl", u.bool2str(in.SSL))
+	params.Set("custom_http_headers", u.bool2str(in.CustomHTTPHeaders))
+	params.Set("mwindows", u.bool2str(in.MaintenanceWindows))
 
 	params.Set("offset", "0")
 	params.Set("limit", "50")
@@ -174,123 +359,74 @@ func (u *UptimeRobot) GetMonitors(in *GetMonitorsInput) ([]Monitor, error) {
 		params.Set("search", in.Search)
 	}
 
-	result := []Monitor{}
-	for {
-		res := &struct {
-			Stat     string `json:"stat"`
-			Offset   int    `json:"offset,string"`
-			Limit    int    `json:"limit,string"`
-			Total    int    `json:"total,string"`
-			ID       int    `json:"id,string"`
-			Monitors struct {
-				Monitors []struct {
-					Monitor
-					Subtype     string `json:"subtype"`
-					KeywordType string `json:"keywordtype"`
-					Port        string `json:"port"`
-				} `json:"monitor"`
-			} `json:"monitors"`
-		}{}
-
-		err := u.doRequest("getMonitors", &params, res)
-		if err != nil {
-			return []Monitor{}, err
-		}
-
-		if res.Stat != "ok" {
-			if res.ID == ErrorAccountHasNoMonitors {
-				return []Monitor{}, nil
-			}
-			return nil, fmt.Errorf("Got unexpected status: %s", res.Stat)
-		}
+	return params, nil
+}
 
-		for _, jm := range res.Monitors.Monitors {
-			m := Monitor{
-				ID:                 jm.ID,
-				FriendlyName:       jm.FriendlyName,
-				URL:                jm.URL,
-				Type:               jm.Type,
-				KeywordValue:       jm.KeywordValue,
-				HTTPUsername:       jm.HTTPUsername,
-				HTTPPassword:       jm.HTTPPassword,
-				Interval:           jm.Interval,
-				Status:             jm.Status,
-				AlltimeUptimeRatio: jm.AlltimeUptimeRatio,
-				CustomUptimeRatio:  jm.CustomUptimeRatio,
-				AlertContacts:      jm.AlertContacts,
-				Logs:               jm.Logs,
-				ResponseTimes:      jm.ResponseTimes,
-			}
-
-			subtype, err := strconv.Atoi(jm.Subtype)
-			if err != nil {
-				m.Subtype = MonitorSubtypeHTTP
-			} else {
-				m.Subtype = MonitorSubtype(subtype)
-			}
-
-			keywordtype, err := strconv.Atoi(jm.KeywordType)
-			if err != nil {
-				m.KeywordType = MonitorKeywordTypeExists
-			} else {
-				m.KeywordType = MonitorKeywordType(keywordtype)
-			}
-
-			port, err := strconv.Atoi(jm.Port)
-			if err != nil {
-				m.Port = 0
-			} else {
-				m.Port = port
-			}
-
-			result = append(result, m)
-		}
+// getMonitorsPage fetches the page of monitors selected by the offset in params
+func (u *UptimeRobot) getMonitorsPage(ctx context.Context, params *url.Values) ([]Monitor, pagination, error) {
+	res := &struct {
+		Stat       string        `json:"stat"`
+		Pagination pagination    `json:"pagination"`
+		Monitors   []monitorJSON `json:"monitors"`
+	}{}
 
-		if res.Offset+res.Limit >= res.Total {
-			break
-		}
+	err := u.doRequest(ctx, "getMonitors", params, res)
+	if errors.Is(err, ErrorAccountHasNoMonitors) {
+		return []Monitor{}, pagination{}, nil
+	}
+	if err != nil {
+		return nil, pagination{}, err
+	}
 
-		params.Set("offset", strconv.FormatInt(int64(res.Offset+res.Limit), 10))
+	result := []Monitor{}
+	for _, jm := range res.Monitors {
+		result = append(result, jm.monitor())
 	}
 
-	return result, nil
+	return result, res.Pagination, nil
 }
 
 // NewOrEditMonitor creates a new monitor if you do not pass an ID in the input,
 // otherwise the monitor is updated
 func (u *UptimeRobot) NewOrEditMonitor(in Monitor) (*Monitor, error) {
+	return u.NewOrEditMonitorContext(context.Background(), in)
+}
+
+// NewOrEditMonitorContext is like NewOrEditMonitor but uses the given context
+// for the request
+func (u *UptimeRobot) NewOrEditMonitorContext(ctx context.Context, in Monitor) (*Monitor, error) {
 	params := &url.Values{}
 
 	if in.FriendlyName == "" || in.URL == "" || in.Type == 0 {
 		return nil, fmt.Errorf("Required parameters misisng. Please check the documentation.")
 	}
 
-	params.Set("monitorFriendlyName", in.FriendlyName)
-	params.Set("monitorURL", in.URL)
-	params.Set("monitorType", strconv.FormatInt(int64(in.Type), 10))
+	params.Set("friendly_name", in.FriendlyName)
+	params.Set("url", in.URL)
+	params.Set("type", strconv.FormatInt(int64(in.Type), 10))
 
 	if in.Subtype != 0 {
-		params.Set("monitorSubType", strconv.FormatInt(int64(in.Subtype), 10))
+		params.Set("sub_type", strconv.FormatInt(int64(in.Subtype), 10))
 	}
 
 	if in.Port != 0 {
-		params.Set("monitorPort", strconv.FormatInt(int64(in.Port), 10))
+		params.Set("port", strconv.FormatInt(int64(in.Port), 10))
 	}
 
 	if in.KeywordType != 0 {
-		params.Set("monitorKeywordType", strconv.FormatInt(int64(in.KeywordType), 10))
+		params.Set("keyword_type", strconv.FormatInt(int64(in.KeywordType), 10))
 	}
 
 	if in.KeywordValue != "" {
-		params.Set("monitorKeywordValue", in.KeywordValue)
+		params.Set("keyword_value", in.KeywordValue)
 	}
 
 	if in.HTTPUsername != "" {
-		params.Set("monitorHTTPUsername", in.HTTPUsername)
+		params.Set("http_username", in.HTTPUsername)
 	}
 
 	if in.HTTPPassword != "" {
-		params.Set("monitorHTTPPassword", in.HTTPPassword)
+		params.Set("http_password", in.HTTPPassword)
 	}
 
 	if len(in.AlertContacts) > 0 {
@@ -298,76 +434,194 @@ func (u *UptimeRobot) NewOrEditMonitor(in Monitor) (*Monitor, error) {
 		for _, i := range in.AlertContacts {
 			m = append(m, fmt.Sprintf("%d_%d_%d", i.ID, i.Threshold, i.Recurrence))
 		}
-		params.Set("monitorAlertContacts", strings.Join(m, "-"))
+		params.Set("alert_contacts", strings.Join(m, "-"))
 	}
 
 	if in.Interval > 0 {
-		params.Set("monitorInterval", strconv.FormatInt(int64(in.Interval), 10))
+		params.Set("interval", strconv.FormatInt(int64(in.Interval), 10))
 	}
 
-	res := &struct {
-		Stat    string  `json:"stat"`
-		Monitor Monitor `json:"monitor"`
-	}{
-		Monitor: in,
+	if len(in.MaintenanceWindows) > 0 {
+		params.Set("mwindows", u.buildIntList(in.MaintenanceWindows))
 	}
 
+	if len(in.CustomHTTPHeaders) > 0 {
+		headers, err := json.Marshal(in.CustomHTTPHeaders)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("custom_http_headers", string(headers))
+	}
+
+	res := &struct {
+		Stat    string `json:"stat"`
+		Monitor struct {
+			ID     flexInt `json:"id"`
+			Status flexInt `json:"status"`
+		} `json:"monitor"`
+	}{}
+
 	var err error
 	if in.ID == 0 {
-		err = u.doRequest("newMonitor", params, res)
+		err = u.doRequest(ctx, "newMonitor", params, res)
 	} else {
-		params.Set("monitorID", strconv.FormatInt(int64(in.ID), 10))
-		err = u.doRequest("editMonitor", params, res)
+		params.Set("id", strconv.FormatInt(int64(in.ID), 10))
+		err = u.doRequest(ctx, "editMonitor", params, res)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Stat == "ok" {
-		return &res.Monitor, nil
+	out := in
+	out.ID = int(res.Monitor.ID)
+	if in.ID == 0 {
+		out.Status = MonitorStatus(res.Monitor.Status)
 	}
-
-	return nil, fmt.Errorf("Got unexpected status: %s", res.Stat)
+	return &out, nil
 }
 
 // DeleteMonitor deletes the monitor identifed by the monitorID
 func (u *UptimeRobot) DeleteMonitor(monitorID int) error {
+	return u.DeleteMonitorContext(context.Background(), monitorID)
+}
+
+// DeleteMonitorContext is like DeleteMonitor but uses the given context for
+// the request
+func (u *UptimeRobot) DeleteMonitorContext(ctx context.Context, monitorID int) error {
 	res := &struct {
 		Stat string `json:"stat"`
 	}{}
 
-	err := u.doRequest("deleteMonitor", &url.Values{
-		"monitorID": []string{strconv.FormatInt(int64(monitorID), 10)},
+	err := u.doRequest(ctx, "deleteMonitor", &url.Values{
+		"id": []string{strconv.FormatInt(int64(monitorID), 10)},
 	}, res)
 
-	if err != nil {
-		return err
-	}
-
-	if res.Stat == "ok" {
-		return nil
-	}
-
-	return fmt.Errorf("Got unexpected status: %s", res.Stat)
+	return err
 }
 
 // ResetMonitor will reset (deleting all stats and response time data) a monitor
 func (u *UptimeRobot) ResetMonitor(monitorID int) error {
+	return u.ResetMonitorContext(context.Background(), monitorID)
+}
+
+// ResetMonitorContext is like ResetMonitor but uses the given context for the
+// request
+func (u *UptimeRobot) ResetMonitorContext(ctx context.Context, monitorID int) error {
 	res := &struct {
 		Stat string `json:"stat"`
 	}{}
 
-	err := u.doRequest("resetMonitor", &url.Values{
-		"monitorID": []string{strconv.FormatInt(int64(monitorID), 10)},
+	err := u.doRequest(ctx, "resetMonitor", &url.Values{
+		"id": []string{strconv.FormatInt(int64(monitorID), 10)},
 	}, res)
 
+	return err
+}
+
+// PauseMonitor pauses the monitor identifed by the monitorID. It reports
+// whether the monitor was running before, pausing a paused monitor is a no-op.
+func (u *UptimeRobot) PauseMonitor(monitorID int) (bool, error) {
+	return u.PauseMonitorContext(context.Background(), monitorID)
+}
+
+// PauseMonitorContext is like PauseMonitor but uses the given context for the
+// requests
+func (u *UptimeRobot) PauseMonitorContext(ctx context.Context, monitorID int) (bool, error) {
+	return u.setMonitorPaused(ctx, monitorID, true)
+}
+
+// ResumeMonitor starts checking the paused monitor identified by the monitorID
+// again. It reports whether the monitor was paused before, resuming a running
+// monitor is a no-op.
+func (u *UptimeRobot) ResumeMonitor(monitorID int) (bool, error) {
+	return u.ResumeMonitorContext(context.Background(), monitorID)
+}
+
+// ResumeMonitorContext is like ResumeMonitor but uses the given context for
+// the requests
+func (u *UptimeRobot) ResumeMonitorContext(ctx context.Context, monitorID int) (bool, error) {
+	return u.setMonitorPaused(ctx, monitorID, false)
+}
+
+// PauseMonitors pauses all monitors matching the filter (nil pauses all
+// monitors of the account) and returns the monitors which were running
+// before. On error the monitors paused so far are returned with the error.
+func (u *UptimeRobot) PauseMonitors(in *GetMonitorsInput) ([]Monitor, error) {
+	return u.PauseMonitorsContext(context.Background(), in)
+}
+
+// PauseMonitorsContext is like PauseMonitors but uses the given context for
+// the requests
+func (u *UptimeRobot) PauseMonitorsContext(ctx context.Context, in *GetMonitorsInput) ([]Monitor, error) {
+	monitors, err := u.GetMonitorsContext(ctx, in)
+	if err != nil {
+		return []Monitor{}, err
+	}
+	return u.setMonitorsPaused(ctx, monitors, true)
+}
+
+// ResumeMonitors resumes all paused monitors matching the filter (nil resumes
+// all monitors of the account) and returns the monitors which were paused
+// before. On error the monitors resumed so far are returned with the error.
+func (u *UptimeRobot) ResumeMonitors(in *GetMonitorsInput) ([]Monitor, error) {
+	return u.ResumeMonitorsContext(context.Background(), in)
+}
+
+// ResumeMonitorsContext is like ResumeMonitors but uses the given context for
+// the requests
+func (u *UptimeRobot) ResumeMonitorsContext(ctx context.Context, in *GetMonitorsInput) ([]Monitor, error) {
+	monitors, err := u.GetMonitorsContext(ctx, in)
+	if err != nil {
+		return []Monitor{}, err
+	}
+	return u.setMonitorsPaused(ctx, monitors, false)
+}
+
+func (u *UptimeRobot) setMonitorPaused(ctx context.Context, monitorID int, pause bool) (bool, error) {
+	monitors, err := u.GetMonitorsContext(ctx, &GetMonitorsInput{Monitors: []int{monitorID}})
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	if len(monitors) == 0 {
+		return false, ErrorMonitorIDNoExists
+	}
+
+	changed, err := u.setMonitorsPaused(ctx, monitors, pause)
+	return len(changed) > 0, err
+}
+
+// setMonitorsPaused pauses or resumes the monitors which are not in the
+// requested state yet and returns them with their new status
+func (u *UptimeRobot) setMonitorsPaused(ctx context.Context, monitors []Monitor, pause bool) ([]Monitor, error) {
+	// editMonitor expects 0 to pause and 1 to resume a monitor, resumed
+	// monitors are not checked yet
+	status := MonitorStatus(MonitorStatusNotCheckedYet)
+	if pause {
+		status = MonitorStatusPaused
 	}
 
-	if res.Stat == "ok" {
-		return nil
+	changed := []Monitor{}
+	for _, m := range monitors {
+		if (m.Status == MonitorStatusPaused) == pause {
+			continue
+		}
+
+		res := &struct {
+			Stat string `json:"stat"`
+		}{}
+
+		err := u.doRequest(ctx, "editMonitor", &url.Values{
+			"id":     []string{strconv.FormatInt(int64(m.ID), 10)},
+			"status": []string{strconv.FormatInt(int64(status), 10)},
+		}, res)
+		if err != nil {
+			return changed, err
+		}
+
+		m.Status = status
+		changed = append(changed, m)
 	}
 
-	return fmt.Errorf("Got unexpected status: %s", res.Stat)
+	return changed, nil
 }
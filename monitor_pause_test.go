@@ -0,0 +1,58 @@
+package uptimerobot_test
+
+import (
+	"errors"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestPauseResumeMonitors(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, Status: uptimerobot.MonitorStatusUp})
+	api := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP, Status: uptimerobot.MonitorStatusDown})
+	srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "ping", URL: "gateway.example.com", Type: uptimerobot.MonitorTypePing, Status: uptimerobot.MonitorStatusUp})
+
+	changed, err := ur.PauseMonitor(web.ID)
+	if err != nil || !changed {
+		t.Fatalf("Expected the monitor to be paused, got %v (%v)", changed, err)
+	}
+
+	if changed, err := ur.PauseMonitor(web.ID); err != nil || changed {
+		t.Errorf("Expected pausing a paused monitor to be a no-op, got %v (%v)", changed, err)
+	}
+
+	paused, err := ur.PauseMonitors(&uptimerobot.GetMonitorsInput{Types: []uptimerobot.MonitorType{uptimerobot.MonitorTypeHTTP}})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(paused) != 1 || paused[0].ID != api.ID || paused[0].Status != uptimerobot.MonitorStatusPaused {
+		t.Errorf("Expected only %q to change, got %+v", api.FriendlyName, paused)
+	}
+
+	resumed, err := ur.ResumeMonitors(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(resumed) != 2 {
+		t.Errorf("Expected 2 monitors to be resumed, got %+v", resumed)
+	}
+
+	for _, m := range srv.Monitors() {
+		if m.Status == uptimerobot.MonitorStatusPaused {
+			t.Errorf("Monitor %q is still paused", m.FriendlyName)
+		}
+	}
+
+	if changed, err := ur.ResumeMonitor(web.ID); err != nil || changed {
+		t.Errorf("Expected resuming a running monitor to be a no-op, got %v (%v)", changed, err)
+	}
+
+	if _, err := ur.PauseMonitor(42); !errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+		t.Errorf("Expected ErrorMonitorIDNoExists, got: %v", err)
+	}
+}
@@ -1,18 +1,58 @@
 package uptimerobot
 
 import (
-	"fmt"
+	"flag"
 	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/satori/go.uuid"
+	"github.com/Jimdo/uptimerobot-api/cassette"
 )
 
-func TestMonitorFlow(t *testing.T) {
+var recordCassettes = flag.Bool("record", false, "record the cassettes in testdata against the API using UR_API_KEY")
+
+// cassetteClient returns a client replaying the exchanges of the named
+// cassette, with -record they are recorded against the API instead. Until the
+// cassette has been recorded the client talks to the API.
+func cassetteClient(t *testing.T, name string) *UptimeRobot {
 	ur := New(os.Getenv("UR_API_KEY"))
-	ur.FullDebug = false
 	ur.disableCaching = true
 
+	mode := cassette.ModeReplay
+	if *recordCassettes {
+		mode = cassette.ModeRecord
+	}
+
+	c, err := cassette.Open(filepath.Join("testdata", "cassettes", name+".json"), mode)
+	if os.IsNotExist(err) {
+		return ur
+	}
+	if err != nil {
+		t.Fatalf("Unable to open cassette: %s", err)
+	}
+
+	if mode == cassette.ModeRecord {
+		t.Cleanup(func() {
+			if err := c.Save(); err != nil {
+				t.Errorf("Unable to save cassette: %s", err)
+			}
+		})
+	} else {
+		t.Cleanup(func() {
+			if unused := c.Unused(); len(unused) > 0 {
+				t.Errorf("%d recorded interactions were not replayed", len(unused))
+			}
+		})
+	}
+
+	ur.HTTPClient = c.Client()
+	return ur
+}
+
+func TestMonitorFlow(t *testing.T) {
+	ur := cassetteClient(t, "monitor_flow")
+	ur.FullDebug = false
+
 	ac := setUpAlertContact(t, ur)
 	monitor := createNewMonitor(t, ur, ac)
 	findAndMatchMonitor(t, ur, monitor, ac)
@@ -25,7 +65,7 @@ func TestMonitorFlow(t *testing.T) {
 func setUpAlertContact(t *testing.T, ur *UptimeRobot) *AlertContact {
 	ac := AlertContact{
 		Type:  AlertContactTypeEMail,
-		Value: fmt.Sprintf("%s@example.com", uuid.NewV4().String()),
+		Value: "uptimerobot-api-test@example.com",
 	}
 
 	ac2, err := ur.NewAlertContact(ac)
@@ -38,12 +78,12 @@ func setUpAlertContact(t *testing.T, ur *UptimeRobot) *AlertContact {
 
 func createNewMonitor(t *testing.T, ur *UptimeRobot, ac *AlertContact) *Monitor {
 	monitor := Monitor{
-		FriendlyName:  uuid.NewV4().String()[0:30],
+		FriendlyName:  "uptimerobot-api test monitor",
 		URL:           "http://www.example.com/",
 		Type:          MonitorTypeHTTP,
 		KeywordType:   MonitorKeywordTypeNotExists,
 		KeywordValue:  "Example Domain",
-		Interval:      10,
+		Interval:      600, // seconds, the v1 API took 10 minutes
 		AlertContacts: []AlertContact{*ac},
 	}
 
@@ -99,7 +139,7 @@ func findAndMatchMonitor(t *testing.T, ur *UptimeRobot, mon *Monitor, ac *AlertC
 }
 
 func updateMonitor(t *testing.T, ur *UptimeRobot, mon *Monitor) *Monitor {
-	mon.FriendlyName = uuid.NewV4().String()[0:30]
+	mon.FriendlyName = "uptimerobot-api test (edited)"
 
 	m, err := ur.NewOrEditMonitor(*mon)
 	if err != nil {
@@ -123,3 +163,28 @@ func deleteMonitor(t *testing.T, ur *UptimeRobot, mon *Monitor) {
 func deleteAlertContact(t *testing.T, ur *UptimeRobot, ac *AlertContact) {
 	ur.DeleteAlertContact(ac.ID)
 }
+
+func TestGetMonitorsParams(t *testing.T) {
+	ur := New("u1234-testkey")
+
+	params, err := ur.getMonitorsParams(&GetMonitorsInput{
+		Monitors:          []int{15, 16},
+		Types:             []MonitorType{MonitorTypeHTTP, MonitorTypePing},
+		Statuses:          []MonitorStatus{MonitorStatusPaused, MonitorStatusDown},
+		CustomUptimeRatio: []int{7, 30},
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	for name, expected := range map[string]string{
+		"monitors":             "15-16",
+		"types":                "1-3",
+		"statuses":             "0-9",
+		"custom_uptime_ratios": "7-30",
+	} {
+		if v := params.Get(name); v != expected {
+			t.Errorf("Expected %s=%q, got %q", name, expected, v)
+		}
+	}
+}
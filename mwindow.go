@@ -0,0 +1,310 @@
+package uptimerobot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaintenanceWindowType represents how often a maintenance window recurs
+type MaintenanceWindowType int
+
+const (
+	_ MaintenanceWindowType = iota
+	MaintenanceWindowTypeOnce
+	MaintenanceWindowTypeDaily
+	MaintenanceWindowTypeWeekly
+	MaintenanceWindowTypeMonthly
+)
+
+type MaintenanceWindowStatus int
+
+const (
+	MaintenanceWindowStatusPaused MaintenanceWindowStatus = iota
+	MaintenanceWindowStatusActive
+)
+
+// mwindowTimeFormat is the format of the start time of recurring windows, it
+// is a wall clock time in the time zone of the account
+const mwindowTimeFormat = "15:04"
+
+// MaintenanceWindow silences the alerts of the attached monitors while it is
+// in effect
+type MaintenanceWindow struct {
+	ID           int
+	Type         MaintenanceWindowType
+	FriendlyName string
+	// the weekdays (1 = Monday ... 7 = Sunday) of weekly windows or the days of
+	// the month (1-31) of monthly windows
+	Days []int
+	// the start of one-time windows, recurring windows only use the time of
+	// day (in the time zone of the account)
+	Start time.Time
+	// the length of the window, the API works in minutes
+	Duration time.Duration
+	Status   MaintenanceWindowStatus
+	// the IDs of the monitors the window applies to (the API stores them as
+	// Monitor.MaintenanceWindows)
+	Monitors []int
+}
+
+// mwindowJSON is the representation of a maintenance window used by the API
+type mwindowJSON struct {
+	ID           flexInt         `json:"id"`
+	Type         flexInt         `json:"type"`
+	FriendlyName string          `json:"friendly_name"`
+	Value        json.RawMessage `json:"value"`
+	// a Unix timestamp for one-time windows and a time of day otherwise
+	StartTime json.RawMessage `json:"start_time"`
+	Duration  flexInt         `json:"duration"`
+	Status    flexInt         `json:"status"`
+}
+
+func (jw mwindowJSON) maintenanceWindow() (MaintenanceWindow, error) {
+	w := MaintenanceWindow{
+		ID:           int(jw.ID),
+		Type:         MaintenanceWindowType(jw.Type),
+		FriendlyName: jw.FriendlyName,
+		Duration:     time.Duration(jw.Duration) * time.Minute,
+		Status:       MaintenanceWindowStatus(jw.Status),
+		Monitors:     []int{},
+	}
+
+	var err error
+	value := rawString(jw.Value)
+	if value == "null" {
+		value = ""
+	}
+	if w.Days, err = parseIntList(value); err != nil {
+		return w, fmt.Errorf("Invalid days %q of maintenance window %d", value, jw.ID)
+	}
+
+	start := rawString(jw.StartTime)
+	if w.Type == MaintenanceWindowTypeOnce {
+		sec, err := strconv.ParseInt(start, 10, 64)
+		if err != nil {
+			return w, fmt.Errorf("Invalid start time %q of maintenance window %d", start, jw.ID)
+		}
+		w.Start = time.Unix(sec, 0).UTC()
+	} else if w.Start, err = time.Parse(mwindowTimeFormat, start); err != nil {
+		return w, fmt.Errorf("Invalid start time %q of maintenance window %d", start, jw.ID)
+	}
+
+	return w, nil
+}
+
+// GetMaintenanceWindows retrieves the maintenance windows with the given IDs
+// (or all of them if no IDs are given)
+func (u *UptimeRobot) GetMaintenanceWindows(windowIDs []int) ([]MaintenanceWindow, error) {
+	return u.GetMaintenanceWindowsContext(context.Background(), windowIDs)
+}
+
+// GetMaintenanceWindowsContext is like GetMaintenanceWindows but uses the given
+// context for the requests. The context is also checked between the pages of
+// the result.
+func (u *UptimeRobot) GetMaintenanceWindowsContext(ctx context.Context, windowIDs []int) ([]MaintenanceWindow, error) {
+	params := &url.Values{
+		"limit":  []string{"50"},
+		"offset": []string{"0"},
+	}
+
+	if len(windowIDs) > 0 {
+		params.Set("mwindows", u.buildIntList(windowIDs))
+	}
+
+	result := []MaintenanceWindow{}
+	p := &pager{
+		ctx:    ctx,
+		params: params,
+		fetch: func(ctx context.Context, params *url.Values) (pagination, error) {
+			page, pg, err := u.getMaintenanceWindowsPage(ctx, params)
+			result = append(result, page...)
+			return pg, err
+		},
+	}
+
+	for p.nextPage() {
+	}
+
+	if err := p.Err(); err != nil {
+		return []MaintenanceWindow{}, err
+	}
+
+	// The monitors reference the windows, not the other way round
+	monitors, err := u.GetMonitorsContext(ctx, &GetMonitorsInput{MaintenanceWindows: true})
+	if err != nil {
+		return []MaintenanceWindow{}, err
+	}
+
+	for i := range result {
+		for _, m := range monitors {
+			if containsInt(m.MaintenanceWindows, result[i].ID) {
+				result[i].Monitors = append(result[i].Monitors, m.ID)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getMaintenanceWindowsPage fetches the page of maintenance windows selected
+// by the offset in params
+func (u *UptimeRobot) getMaintenanceWindowsPage(ctx context.Context, params *url.Values) ([]MaintenanceWindow, pagination, error) {
+	res := &struct {
+		Stat               string        `json:"stat"`
+		Pagination         pagination    `json:"pagination"`
+		MaintenanceWindows []mwindowJSON `json:"mwindows"`
+	}{}
+
+	err := u.doRequest(ctx, "getMWindows", params, res)
+	if err != nil {
+		return nil, pagination{}, err
+	}
+
+	result := []MaintenanceWindow{}
+	for _, jw := range res.MaintenanceWindows {
+		w, err := jw.maintenanceWindow()
+		if err != nil {
+			return nil, pagination{}, err
+		}
+		result = append(result, w)
+	}
+
+	return result, res.Pagination, nil
+}
+
+// NewOrEditMaintenanceWindow creates a new maintenance window if you do not
+// pass an ID in the input, otherwise the maintenance window is updated. Edits
+// send all fields including the Status, so start from a window returned by
+// GetMaintenanceWindows. If Monitors is not empty, the window is added to the
+// listed monitors and removed from all others.
+func (u *UptimeRobot) NewOrEditMaintenanceWindow(in MaintenanceWindow) (*MaintenanceWindow, error) {
+	return u.NewOrEditMaintenanceWindowContext(context.Background(), in)
+}
+
+// NewOrEditMaintenanceWindowContext is like NewOrEditMaintenanceWindow but
+// uses the given context for the request
+func (u *UptimeRobot) NewOrEditMaintenanceWindowContext(ctx context.Context, in MaintenanceWindow) (*MaintenanceWindow, error) {
+	params := &url.Values{}
+
+	if in.FriendlyName == "" || in.Type == 0 || in.Start.IsZero() || in.Duration <= 0 {
+		return nil, fmt.Errorf("Required parameters misisng. Please check the documentation.")
+	}
+
+	if in.Duration%time.Minute != 0 {
+		return nil, fmt.Errorf("Duration has to be a multiple of a minute")
+	}
+
+	params.Set("friendly_name", in.FriendlyName)
+	params.Set("type", strconv.FormatInt(int64(in.Type), 10))
+	params.Set("duration", strconv.FormatInt(int64(in.Duration/time.Minute), 10))
+
+	switch in.Type {
+	case MaintenanceWindowTypeOnce:
+		params.Set("start_time", strconv.FormatInt(in.Start.Unix(), 10))
+	case MaintenanceWindowTypeWeekly, MaintenanceWindowTypeMonthly:
+		if len(in.Days) == 0 {
+			return nil, fmt.Errorf("Days are required for weekly and monthly maintenance windows")
+		}
+		params.Set("value", u.buildIntList(in.Days))
+		fallthrough
+	default:
+		params.Set("start_time", in.Start.Format(mwindowTimeFormat))
+	}
+
+	res := &struct {
+		Stat              string `json:"stat"`
+		MaintenanceWindow struct {
+			ID     flexInt `json:"id"`
+			Status flexInt `json:"status"`
+		} `json:"mwindow"`
+	}{}
+
+	var err error
+	if in.ID == 0 {
+		err = u.doRequest(ctx, "newMWindow", params, res)
+	} else {
+		params.Set("id", strconv.FormatInt(int64(in.ID), 10))
+		params.Set("status", strconv.FormatInt(int64(in.Status), 10))
+		err = u.doRequest(ctx, "editMWindow", params, res)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := in
+	if in.ID == 0 {
+		out.ID = int(res.MaintenanceWindow.ID)
+		out.Status = MaintenanceWindowStatus(res.MaintenanceWindow.Status)
+	}
+
+	if len(in.Monitors) > 0 {
+		if err := u.attachMaintenanceWindow(ctx, out.ID, in.Monitors); err != nil {
+			return &out, err
+		}
+	}
+	return &out, nil
+}
+
+// attachMaintenanceWindow adds the window to the maintenance windows of the
+// given monitors and removes it from all other monitors
+func (u *UptimeRobot) attachMaintenanceWindow(ctx context.Context, windowID int, monitorIDs []int) error {
+	monitors, err := u.GetMonitorsContext(ctx, &GetMonitorsInput{MaintenanceWindows: true})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range monitors {
+		attach := containsInt(monitorIDs, m.ID)
+		if attach == containsInt(m.MaintenanceWindows, windowID) {
+			continue
+		}
+
+		windows := []int{}
+		for _, id := range m.MaintenanceWindows {
+			if id != windowID {
+				windows = append(windows, id)
+			}
+		}
+		if attach {
+			windows = append(windows, windowID)
+		}
+
+		res := &struct {
+			Stat string `json:"stat"`
+		}{}
+
+		err := u.doRequest(ctx, "editMonitor", &url.Values{
+			"id":       []string{strconv.FormatInt(int64(m.ID), 10)},
+			"mwindows": []string{u.buildIntList(windows)},
+		}, res)
+		if err != nil {
+			return fmt.Errorf("Unable to update the maintenance windows of monitor %d: %s", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window identified by the
+// windowID
+func (u *UptimeRobot) DeleteMaintenanceWindow(windowID int) error {
+	return u.DeleteMaintenanceWindowContext(context.Background(), windowID)
+}
+
+// DeleteMaintenanceWindowContext is like DeleteMaintenanceWindow but uses the
+// given context for the request
+func (u *UptimeRobot) DeleteMaintenanceWindowContext(ctx context.Context, windowID int) error {
+	res := &struct {
+		Stat string `json:"stat"`
+	}{}
+
+	err := u.doRequest(ctx, "deleteMWindow", &url.Values{
+		"id": []string{strconv.FormatInt(int64(windowID), 10)},
+	}, res)
+
+	return err
+}
@@ -0,0 +1,76 @@
+package uptimerobot_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	m := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+
+	w, err := ur.NewOrEditMaintenanceWindow(uptimerobot.MaintenanceWindow{
+		Type:         uptimerobot.MaintenanceWindowTypeWeekly,
+		FriendlyName: "deploys",
+		Days:         []int{2, 4},
+		Start:        time.Date(0, 1, 1, 18, 30, 0, 0, time.UTC),
+		Duration:     45 * time.Minute,
+		Monitors:     []int{m.ID},
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if w.ID == 0 || w.Status != uptimerobot.MaintenanceWindowStatusActive {
+		t.Errorf("Unexpected window: %+v", w)
+	}
+
+	ws, err := ur.GetMaintenanceWindows([]int{w.ID})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(ws) != 1 || ws[0].Start.Hour() != 18 || ws[0].Start.Minute() != 30 || ws[0].Duration != 45*time.Minute || fmt.Sprint(ws[0].Days) != "[2 4]" || fmt.Sprint(ws[0].Monitors) != fmt.Sprint([]int{m.ID}) {
+		t.Errorf("Unexpected windows: %+v", ws)
+	}
+
+	edited := ws[0]
+	edited.Type = uptimerobot.MaintenanceWindowTypeOnce
+	edited.Start = time.Date(2017, 3, 1, 22, 0, 0, 0, time.UTC)
+	edited.Status = uptimerobot.MaintenanceWindowStatusPaused
+	if _, err := ur.NewOrEditMaintenanceWindow(edited); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	ws, err = ur.GetMaintenanceWindows(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(ws) != 1 || !ws[0].Start.Equal(edited.Start) || len(ws[0].Days) != 0 || ws[0].Status != uptimerobot.MaintenanceWindowStatusPaused {
+		t.Errorf("Window was not edited as expected: %+v", ws)
+	}
+
+	if err := ur.DeleteMonitor(m.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if ws := srv.MaintenanceWindows(); len(ws[0].Monitors) != 0 {
+		t.Errorf("Expected the deleted monitor to be detached: %+v", ws)
+	}
+
+	if err := ur.DeleteMaintenanceWindow(w.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if err := ur.DeleteMaintenanceWindow(w.ID); !errors.Is(err, uptimerobot.ErrorMWindowIDNotExists) {
+		t.Errorf("Expected ErrorMWindowIDNotExists, got: %v", err)
+	}
+}
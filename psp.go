@@ -0,0 +1,296 @@
+package uptimerobot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// PublicStatusPageSort represents the order of the monitors on a status page
+type PublicStatusPageSort int
+
+const (
+	_ PublicStatusPageSort = iota
+	PublicStatusPageSortFriendlyNameAZ
+	PublicStatusPageSortFriendlyNameZA
+	PublicStatusPageSortStatusUpDown
+	PublicStatusPageSortStatusDownUp
+)
+
+type PublicStatusPageStatus int
+
+const (
+	PublicStatusPageStatusPaused PublicStatusPageStatus = iota
+	PublicStatusPageStatusActive
+)
+
+// PublicStatusPage (PSP) publishes the status of a list of monitors
+type PublicStatusPage struct {
+	ID           int
+	FriendlyName string
+	// the IDs of the monitors shown on the page, empty for all monitors of
+	// the account
+	Monitors []int
+	// optional (a domain pointing to the page, Example: status.example.com)
+	CustomDomain string
+	// optional (protects the page, it is never returned by the API)
+	Password string
+	Sort     PublicStatusPageSort
+	Status   PublicStatusPageStatus
+	// the address of the page at UptimeRobot (read-only)
+	StandardURL string
+}
+
+// pspJSON is the representation of a status page used by the API
+type pspJSON struct {
+	ID           flexInt `json:"id"`
+	FriendlyName string  `json:"friendly_name"`
+	// a list of monitor IDs or 0 for all monitors of the account
+	Monitors    json.RawMessage `json:"monitors"`
+	CustomURL   string          `json:"custom_url"`
+	Sort        flexInt         `json:"sort"`
+	Status      flexInt         `json:"status"`
+	StandardURL string          `json:"standard_url"`
+}
+
+// GetPublicStatusPages retrieves the status pages with the given IDs (or all
+// of them if no IDs are given)
+func (u *UptimeRobot) GetPublicStatusPages(pspIDs []int) ([]PublicStatusPage, error) {
+	return u.GetPublicStatusPagesContext(context.Background(), pspIDs)
+}
+
+// GetPublicStatusPagesContext is like GetPublicStatusPages but uses the given
+// context for the requests. The context is also checked between the pages of
+// the result.
+func (u *UptimeRobot) GetPublicStatusPagesContext(ctx context.Context, pspIDs []int) ([]PublicStatusPage, error) {
+	params := &url.Values{
+		"limit":  []string{"50"},
+		"offset": []string{"0"},
+	}
+
+	if len(pspIDs) > 0 {
+		params.Set("psps", u.buildIntList(pspIDs))
+	}
+
+	result := []PublicStatusPage{}
+	p := &pager{
+		ctx:    ctx,
+		params: params,
+		fetch: func(ctx context.Context, params *url.Values) (pagination, error) {
+			page, pg, err := u.getPublicStatusPagesPage(ctx, params)
+			result = append(result, page...)
+			return pg, err
+		},
+	}
+
+	for p.nextPage() {
+	}
+
+	if err := p.Err(); err != nil {
+		return []PublicStatusPage{}, err
+	}
+
+	return result, nil
+}
+
+// getPublicStatusPagesPage fetches the page of status pages selected by the
+// offset in params
+func (u *UptimeRobot) getPublicStatusPagesPage(ctx context.Context, params *url.Values) ([]PublicStatusPage, pagination, error) {
+	res := &struct {
+		Stat       string     `json:"stat"`
+		Pagination pagination `json:"pagination"`
+		PSPs       []pspJSON  `json:"psps"`
+	}{}
+
+	err := u.doRequest(ctx, "getPSPs", params, res)
+	if err != nil {
+		return nil, pagination{}, err
+	}
+
+	result := []PublicStatusPage{}
+	for _, jp := range res.PSPs {
+		p := PublicStatusPage{
+			ID:           int(jp.ID),
+			FriendlyName: jp.FriendlyName,
+			CustomDomain: jp.CustomURL,
+			Sort:         PublicStatusPageSort(jp.Sort),
+			Status:       PublicStatusPageStatus(jp.Status),
+			StandardURL:  jp.StandardURL,
+		}
+
+		// 0 stands for all monitors of the account
+		if monitors := rawString(jp.Monitors); monitors != "0" && monitors != "" {
+			if err := json.Unmarshal(jp.Monitors, &p.Monitors); err != nil {
+				return nil, pagination{}, fmt.Errorf("Invalid monitors %s of status page %d", monitors, jp.ID)
+			}
+		}
+
+		result = append(result, p)
+	}
+
+	return result, res.Pagination, nil
+}
+
+// NewOrEditPublicStatusPage creates a new status page if you do not pass an ID
+// in the input, otherwise the status page is updated. Edits send all fields
+// except an empty Password, so start from a page returned by
+// GetPublicStatusPages.
+func (u *UptimeRobot) NewOrEditPublicStatusPage(in PublicStatusPage) (*PublicStatusPage, error) {
+	return u.NewOrEditPublicStatusPageContext(context.Background(), in)
+}
+
+// NewOrEditPublicStatusPageContext is like NewOrEditPublicStatusPage but uses
+// the given context for the request
+func (u *UptimeRobot) NewOrEditPublicStatusPageContext(ctx context.Context, in PublicStatusPage) (*PublicStatusPage, error) {
+	params := &url.Values{}
+
+	if in.FriendlyName == "" {
+		return nil, fmt.Errorf("Required parameters misisng. Please check the documentation.")
+	}
+
+	params.Set("friendly_name", in.FriendlyName)
+
+	if len(in.Monitors) > 0 {
+		params.Set("monitors", u.buildIntList(in.Monitors))
+	} else {
+		params.Set("monitors", "0")
+	}
+
+	params.Set("custom_domain", in.CustomDomain)
+
+	if in.Password != "" {
+		params.Set("password", in.Password)
+	}
+
+	if in.Sort != 0 {
+		params.Set("sort", strconv.FormatInt(int64(in.Sort), 10))
+	}
+
+	res := &struct {
+		Stat string `json:"stat"`
+		PSP  struct {
+			ID     flexInt `json:"id"`
+			Status flexInt `json:"status"`
+		} `json:"psp"`
+	}{}
+
+	var err error
+	if in.ID == 0 {
+		err = u.doRequest(ctx, "newPSP", params, res)
+	} else {
+		params.Set("id", strconv.FormatInt(int64(in.ID), 10))
+		params.Set("status", strconv.FormatInt(int64(in.Status), 10))
+		err = u.doRequest(ctx, "editPSP", params, res)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := in
+	if in.ID == 0 {
+		out.ID = int(res.PSP.ID)
+		out.Status = PublicStatusPageStatus(res.PSP.Status)
+	}
+	return &out, nil
+}
+
+// DeletePublicStatusPage deletes the status page identified by the pspID
+func (u *UptimeRobot) DeletePublicStatusPage(pspID int) error {
+	return u.DeletePublicStatusPageContext(context.Background(), pspID)
+}
+
+// DeletePublicStatusPageContext is like DeletePublicStatusPage but uses the
+// given context for the request
+func (u *UptimeRobot) DeletePublicStatusPageContext(ctx context.Context, pspID int) error {
+	res := &struct {
+		Stat string `json:"stat"`
+	}{}
+
+	err := u.doRequest(ctx, "deletePSP", &url.Values{
+		"id": []string{strconv.FormatInt(int64(pspID), 10)},
+	}, res)
+
+	return err
+}
+
+// SyncPublicStatusPageMonitors sets the monitors of the status page to the
+// monitors matching the filter (see GetMonitors) and returns the IDs of the
+// monitors which were added and removed. The page is not touched if its
+// monitors match already. As a page without monitors shows all monitors of
+// the account, a filter matching no monitors is an error.
+func (u *UptimeRobot) SyncPublicStatusPageMonitors(pspID int, in *GetMonitorsInput) (added, removed []int, err error) {
+	return u.SyncPublicStatusPageMonitorsContext(context.Background(), pspID, in)
+}
+
+// SyncPublicStatusPageMonitorsContext is like SyncPublicStatusPageMonitors but
+// uses the given context for the requests
+func (u *UptimeRobot) SyncPublicStatusPageMonitorsContext(ctx context.Context, pspID int, in *GetMonitorsInput) (added, removed []int, err error) {
+	psps, err := u.GetPublicStatusPagesContext(ctx, []int{pspID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(psps) != 1 {
+		return nil, nil, ErrorPSPIDNotExists
+	}
+	psp := psps[0]
+
+	monitors, err := u.GetMonitorsContext(ctx, in)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(monitors) == 0 {
+		return nil, nil, fmt.Errorf("No monitors match the filter for status page %d", pspID)
+	}
+
+	desired := map[int]bool{}
+	for _, m := range monitors {
+		desired[m.ID] = true
+	}
+
+	// An empty list shows all monitors, so everything not desired is removed
+	current := map[int]bool{}
+	if len(psp.Monitors) == 0 {
+		all, err := u.GetMonitorsContext(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, m := range all {
+			current[m.ID] = true
+		}
+	}
+	for _, id := range psp.Monitors {
+		current[id] = true
+	}
+
+	added, removed = []int{}, []int{}
+	for id := range desired {
+		if !current[id] {
+			added = append(added, id)
+		}
+	}
+	for id := range current {
+		if !desired[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Ints(added)
+	sort.Ints(removed)
+
+	if len(added) == 0 && len(removed) == 0 && len(psp.Monitors) > 0 {
+		return added, removed, nil
+	}
+
+	psp.Monitors = make([]int, 0, len(desired))
+	for id := range desired {
+		psp.Monitors = append(psp.Monitors, id)
+	}
+	sort.Ints(psp.Monitors)
+
+	if _, err := u.NewOrEditPublicStatusPageContext(ctx, psp); err != nil {
+		return nil, nil, err
+	}
+	return added, removed, nil
+}
@@ -0,0 +1,70 @@
+package uptimerobot_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestPublicStatusPages(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	api := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	ping := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "gateway", URL: "gateway.example.com", Type: uptimerobot.MonitorTypePing})
+
+	p, err := ur.NewOrEditPublicStatusPage(uptimerobot.PublicStatusPage{
+		FriendlyName: "status",
+		Monitors:     []int{ping.ID},
+		CustomDomain: "status.example.com",
+		Password:     "s3cr3t",
+		Sort:         uptimerobot.PublicStatusPageSortStatusDownUp,
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	psps, err := ur.GetPublicStatusPages(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(psps) != 1 || psps[0].ID != p.ID || psps[0].CustomDomain != "status.example.com" || psps[0].Sort != uptimerobot.PublicStatusPageSortStatusDownUp || psps[0].Password != "" || psps[0].StandardURL == "" {
+		t.Errorf("Unexpected status pages: %+v", psps)
+	}
+
+	added, removed, err := ur.SyncPublicStatusPageMonitors(p.ID, &uptimerobot.GetMonitorsInput{Types: []uptimerobot.MonitorType{uptimerobot.MonitorTypeHTTP}})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if fmt.Sprint(added) != fmt.Sprint([]int{web.ID, api.ID}) || fmt.Sprint(removed) != fmt.Sprint([]int{ping.ID}) {
+		t.Errorf("Unexpected changes: added %v, removed %v", added, removed)
+	}
+
+	if psps := srv.PublicStatusPages(); fmt.Sprint(psps[0].Monitors) != fmt.Sprint([]int{web.ID, api.ID}) || psps[0].Password != "s3cr3t" {
+		t.Errorf("Status page was not synced as expected: %+v", psps[0])
+	}
+
+	added, removed, err = ur.SyncPublicStatusPageMonitors(p.ID, &uptimerobot.GetMonitorsInput{Types: []uptimerobot.MonitorType{uptimerobot.MonitorTypeHTTP}})
+	if err != nil || len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected no changes, got added %v, removed %v (%v)", added, removed, err)
+	}
+
+	if _, _, err := ur.SyncPublicStatusPageMonitors(p.ID, &uptimerobot.GetMonitorsInput{Search: "nothing"}); err == nil {
+		t.Errorf("Expected an error for a filter matching no monitors")
+	}
+
+	if err := ur.DeletePublicStatusPage(p.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if _, _, err := ur.SyncPublicStatusPageMonitors(p.ID, nil); !errors.Is(err, uptimerobot.ErrorPSPIDNotExists) {
+		t.Errorf("Expected ErrorPSPIDNotExists, got: %v", err)
+	}
+}
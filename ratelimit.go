@@ -0,0 +1,127 @@
+package uptimerobot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitPolicy selects how a RateLimiter behaves once it ran out of tokens
+type RateLimitPolicy int
+
+const (
+	// RateLimitWait blocks the request until a token is available or the
+	// context of the request is done
+	RateLimitWait RateLimitPolicy = iota
+	// RateLimitFailFast returns a *RateLimitError instead of waiting
+	RateLimitFailFast
+)
+
+// RateLimitError is returned by the client methods if the RateLimiter uses the
+// RateLimitFailFast policy and no request may be sent at the moment
+type RateLimitError struct {
+	// the time until the next request would be allowed
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("Client side rate limit exceeded, retry in %s", e.RetryAfter)
+}
+
+// RateLimiter is a token bucket limiting the number of requests sent to the
+// API. A single RateLimiter may be assigned to several clients, every request
+// of any of them takes one token.
+type RateLimiter struct {
+	// Policy defines what happens to requests exceeding the limit
+	Policy RateLimitPolicy
+
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit requests per given
+// duration (Example: NewRateLimiter(10, time.Minute)). Up to limit requests
+// may be sent in a burst, after that they are spread evenly. A limit below
+// one is raised to one and a duration which is not positive is replaced by a
+// minute.
+func NewRateLimiter(limit int, per time.Duration) *RateLimiter {
+	if limit < 1 {
+		limit = 1
+	}
+	if per <= 0 {
+		per = time.Minute
+	}
+
+	return &RateLimiter{
+		Policy: RateLimitWait,
+		rate:   float64(limit) / per.Seconds(),
+		burst:  float64(limit),
+		tokens: float64(limit),
+		last:   time.Now(),
+	}
+}
+
+var (
+	sharedRateLimiters   = map[string]*RateLimiter{}
+	sharedRateLimitersMu sync.Mutex
+)
+
+// SharedRateLimiter returns the RateLimiter registered for the given API-key,
+// creating it with NewRateLimiter(limit, per) on the first call. Assign it to
+// every client using the same API-key to let them share one budget. The limit
+// of the first call applies to all of them, later calls return the registered
+// limiter and ignore their limit and per.
+func SharedRateLimiter(apikey string, limit int, per time.Duration) *RateLimiter {
+	sharedRateLimitersMu.Lock()
+	defer sharedRateLimitersMu.Unlock()
+
+	if l, ok := sharedRateLimiters[apikey]; ok {
+		return l
+	}
+
+	l := NewRateLimiter(limit, per)
+	sharedRateLimiters[apikey] = l
+	return l
+}
+
+// Wait takes a token from the bucket according to the Policy of the limiter
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill(time.Now())
+
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if l.Policy == RateLimitFailFast {
+		l.mu.Unlock()
+		return &RateLimitError{RetryAfter: wait}
+	}
+
+	// Reserve the token now so concurrent callers queue up behind us
+	l.tokens--
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
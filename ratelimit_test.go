@@ -0,0 +1,92 @@
+package uptimerobot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterFailFast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"stat":"ok","account":{"monitor_limit":50}}`)
+	}))
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL
+	ur.RateLimiter = NewRateLimiter(2, time.Minute)
+	ur.RateLimiter.Policy = RateLimitFailFast
+
+	for i := 0; i < 2; i++ {
+		if _, err := ur.GetAccountDetails(); err != nil {
+			t.Fatalf("Test errored: %s", err)
+		}
+	}
+
+	_, err := ur.GetAccountDetails()
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("Expected a RateLimitError, got: %v", err)
+	}
+
+	if rlErr.RetryAfter <= 0 || rlErr.RetryAfter > 30*time.Second {
+		t.Errorf("Unexpected RetryAfter: %s", rlErr.RetryAfter)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(1, 50*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Test errored: %s", err)
+		}
+	}
+
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Errorf("Expected the limiter to block, took only %s", d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	a := SharedRateLimiter("key-a", 10, time.Minute)
+	b := SharedRateLimiter("key-b", 10, time.Minute)
+
+	if SharedRateLimiter("key-a", 5, time.Second) != a {
+		t.Errorf("Expected the same limiter for the same API-key")
+	}
+
+	if a == b {
+		t.Errorf("Expected different limiters for different API-keys")
+	}
+}
+
+func TestNewRateLimiterClampsInvalidArguments(t *testing.T) {
+	for _, tc := range []struct {
+		limit    int
+		per      time.Duration
+		expected float64 // tokens per second
+	}{
+		{0, time.Minute, 1.0 / 60},
+		{-1, time.Second, 1},
+		{30, 0, 0.5},
+		{30, -time.Second, 0.5},
+	} {
+		l := NewRateLimiter(tc.limit, tc.per)
+		if l.rate != tc.expected || l.burst < 1 {
+			t.Errorf("NewRateLimiter(%d, %s): Expected %g tokens per second, got %g with a burst of %g", tc.limit, tc.per, tc.expected, l.rate, l.burst)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package reconcile
+
+import (
+	"fmt"
+	"strings"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Action is the kind of change planned for a resource
+type Action int
+
+const (
+	// ActionCreate creates a resource which does not exist yet
+	ActionCreate Action = iota
+	// ActionUpdate modifies an existing resource in place
+	ActionUpdate
+	// ActionReplace deletes an existing resource and creates it again because
+	// the changed fields cannot be modified in place
+	ActionReplace
+	// ActionDelete deletes a resource which is not desired anymore
+	ActionDelete
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionReplace:
+		return "replace"
+	case ActionDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+func (a Action) symbol() string {
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionUpdate:
+		return "~"
+	case ActionReplace:
+		return "-/+"
+	case ActionDelete:
+		return "-"
+	}
+	return "?"
+}
+
+// FieldDiff describes the change of a single field
+type FieldDiff struct {
+	// the name of the field (Example: "URL")
+	Field string
+	// the current value, formatted for display
+	Old string
+	// the desired value, formatted for display
+	New string
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s => %s", d.Field, d.Old, d.New)
+}
+
+// MonitorChange is a planned change of a single monitor
+type MonitorChange struct {
+	Action Action
+	// the key the monitor was matched by
+	Key string
+	// the monitor as it exists in the account (nil for ActionCreate)
+	Current *uptimerobot.Monitor
+	// the monitor as it is desired (nil for ActionDelete)
+	Desired *uptimerobot.Monitor
+	// the changed fields (for ActionUpdate and ActionReplace)
+	Diffs []FieldDiff
+}
+
+// AlertContactChange is a planned change of a single alert contact
+type AlertContactChange struct {
+	Action Action
+	// the friendly name the alert contact was matched by
+	Key string
+	// the alert contact as it exists in the account (nil for ActionCreate)
+	Current *uptimerobot.AlertContact
+	// the alert contact as it is desired (nil for ActionDelete)
+	Desired *uptimerobot.AlertContact
+	// the changed fields (for ActionUpdate and ActionReplace)
+	Diffs []FieldDiff
+}
+
+// Plan is the list of changes required to bring an account into the desired
+// state. It is created by NewPlan and executed by Apply.
+type Plan struct {
+	AlertContacts []AlertContactChange
+	Monitors      []MonitorChange
+
+	// the IDs of all alert contacts by friendly name, completed during Apply
+	contactIDs map[string]int
+}
+
+// Empty reports whether the account already is in the desired state
+func (p *Plan) Empty() bool {
+	return len(p.AlertContacts) == 0 && len(p.Monitors) == 0
+}
+
+// Counts returns the number of resources to be created, updated (including
+// replacements) and deleted
+func (p *Plan) Counts() (create, update, del int) {
+	count := func(a Action) {
+		switch a {
+		case ActionCreate:
+			create++
+		case ActionUpdate, ActionReplace:
+			update++
+		case ActionDelete:
+			del++
+		}
+	}
+
+	for _, c := range p.AlertContacts {
+		count(c.Action)
+	}
+	for _, c := range p.Monitors {
+		count(c.Action)
+	}
+	return
+}
+
+// String renders the plan for humans, one line per resource followed by the
+// changed fields
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes. The account matches the desired state.\n"
+	}
+
+	b := &strings.Builder{}
+	for _, c := range p.AlertContacts {
+		fmt.Fprintf(b, "%s alert contact %q\n", c.Action.symbol(), c.Key)
+		for _, d := range c.Diffs {
+			fmt.Fprintf(b, "    %s\n", d)
+		}
+	}
+	for _, c := range p.Monitors {
+		fmt.Fprintf(b, "%s monitor %q\n", c.Action.symbol(), c.Key)
+		for _, d := range c.Diffs {
+			fmt.Fprintf(b, "    %s\n", d)
+		}
+	}
+
+	create, update, del := p.Counts()
+	fmt.Fprintf(b, "Plan: %d to create, %d to update, %d to delete.\n", create, update, del)
+	return b.String()
+}
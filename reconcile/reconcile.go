@@ -0,0 +1,362 @@
+// Package reconcile brings monitors and alert contacts of an UptimeRobot
+// account into a declared state.
+//
+// NewPlan compares the desired definitions against the account and returns a
+// Plan listing the resources to create, update and delete together with the
+// changed fields. Apply executes the plan:
+//
+//	plan, err := reconcile.NewPlan(ctx, ur, desired, reconcile.Options{})
+//	if err != nil {
+//		...
+//	}
+//	fmt.Print(plan)
+//	err = plan.Apply(ctx, ur)
+//
+// Monitors are matched by a stable key (their friendly name unless configured
+// otherwise), alert contacts by their friendly name. Desired monitors refer to
+// their alert contacts by friendly name, the numeric IDs are resolved while
+// planning and applying. Fields left at their zero value in a desired
+// definition are not managed and keep whatever value the account has.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Desired is the state an account should be brought into
+type Desired struct {
+	// the desired alert contacts, FriendlyName is required for every contact
+	AlertContacts []uptimerobot.AlertContact
+	// the desired monitors, FriendlyName, URL and Type are required for every
+	// monitor. Alert contacts are referenced by their FriendlyName (or by ID
+	// for contacts not managed here) and carry Threshold and Recurrence.
+	Monitors []uptimerobot.Monitor
+}
+
+// KeyFunc returns the key a monitor is identified by when comparing the
+// desired with the current state
+type KeyFunc func(m uptimerobot.Monitor) string
+
+// KeyFriendlyName identifies monitors by their friendly name
+func KeyFriendlyName(m uptimerobot.Monitor) string {
+	return m.FriendlyName
+}
+
+// KeyURL identifies monitors by their URL
+func KeyURL(m uptimerobot.Monitor) string {
+	return m.URL
+}
+
+// Options control how the desired state is compared with the account
+type Options struct {
+	// Key identifies monitors (defaults to KeyFriendlyName)
+	Key KeyFunc
+	// PruneMonitors deletes monitors which are not part of the desired state
+	PruneMonitors bool
+	// PruneAlertContacts deletes alert contacts which are not part of the
+	// desired state
+	PruneAlertContacts bool
+}
+
+// NewPlan fetches the monitors and alert contacts of the account and computes
+// the changes required to reach the desired state
+func NewPlan(ctx context.Context, ur *uptimerobot.UptimeRobot, desired Desired, opts Options) (*Plan, error) {
+	if opts.Key == nil {
+		opts.Key = KeyFriendlyName
+	}
+
+	contacts, err := ur.GetAlertContactsContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := ur.GetMonitorsContext(ctx, &uptimerobot.GetMonitorsInput{ShowMonitorAlertContacts: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return planChanges(contacts, monitors, desired, opts)
+}
+
+func planChanges(contacts []uptimerobot.AlertContact, monitors []uptimerobot.Monitor, desired Desired, opts Options) (*Plan, error) {
+	plan := &Plan{contactIDs: map[string]int{}}
+
+	currentContacts := map[string]uptimerobot.AlertContact{}
+	for _, c := range contacts {
+		if c.FriendlyName == "" {
+			continue
+		}
+		if _, ok := currentContacts[c.FriendlyName]; ok {
+			return nil, fmt.Errorf("Alert contact %q exists more than once in the account", c.FriendlyName)
+		}
+		currentContacts[c.FriendlyName] = c
+		plan.contactIDs[c.FriendlyName] = c.ID
+	}
+
+	desiredContacts := map[string]bool{}
+	replaced := map[string]bool{}
+	for i := range desired.AlertContacts {
+		d := desired.AlertContacts[i]
+		if d.FriendlyName == "" {
+			return nil, fmt.Errorf("Desired alert contact %d has no FriendlyName", i)
+		}
+		if desiredContacts[d.FriendlyName] {
+			return nil, fmt.Errorf("Desired alert contact %q is defined more than once", d.FriendlyName)
+		}
+		desiredContacts[d.FriendlyName] = true
+
+		cur, ok := currentContacts[d.FriendlyName]
+		if !ok {
+			plan.AlertContacts = append(plan.AlertContacts, AlertContactChange{Action: ActionCreate, Key: d.FriendlyName, Desired: &d})
+			continue
+		}
+
+		diffs := diffAlertContact(cur, d)
+		switch {
+		case hasDiff(diffs, "Type"):
+			// The type of an alert contact cannot be edited
+			plan.AlertContacts = append(plan.AlertContacts, AlertContactChange{Action: ActionReplace, Key: d.FriendlyName, Current: &cur, Desired: &d, Diffs: diffs})
+			replaced[d.FriendlyName] = true
+		case len(diffs) > 0:
+			plan.AlertContacts = append(plan.AlertContacts, AlertContactChange{Action: ActionUpdate, Key: d.FriendlyName, Current: &cur, Desired: &d, Diffs: diffs})
+		}
+	}
+
+	currentMonitors := map[string]uptimerobot.Monitor{}
+	for _, m := range monitors {
+		key := opts.Key(m)
+		if _, ok := currentMonitors[key]; ok {
+			return nil, fmt.Errorf("Monitor %q exists more than once in the account", key)
+		}
+		currentMonitors[key] = m
+	}
+
+	desiredMonitors := map[string]bool{}
+	for i := range desired.Monitors {
+		d := desired.Monitors[i]
+		key := opts.Key(d)
+		if key == "" || d.FriendlyName == "" || d.URL == "" || d.Type == 0 {
+			return nil, fmt.Errorf("Desired monitor %d (%q) lacks one of FriendlyName, URL or Type", i, key)
+		}
+		if desiredMonitors[key] {
+			return nil, fmt.Errorf("Desired monitor %q is defined more than once", key)
+		}
+		desiredMonitors[key] = true
+
+		for _, ac := range d.AlertContacts {
+			if ac.ID == 0 && !desiredContacts[ac.FriendlyName] && plan.contactIDs[ac.FriendlyName] == 0 {
+				return nil, fmt.Errorf("Monitor %q refers to unknown alert contact %q", key, ac.FriendlyName)
+			}
+		}
+
+		cur, ok := currentMonitors[key]
+		if !ok {
+			plan.Monitors = append(plan.Monitors, MonitorChange{Action: ActionCreate, Key: key, Desired: &d})
+			continue
+		}
+
+		diffs := diffMonitor(cur, d, plan.contactIDs)
+		for _, ac := range d.AlertContacts {
+			if ac.ID == 0 && replaced[ac.FriendlyName] && !hasDiff(diffs, "AlertContacts") {
+				diffs = append(diffs, FieldDiff{Field: "AlertContacts", Old: formatContacts(cur.AlertContacts), New: fmt.Sprintf("%s (%q is replaced)", formatContacts(d.AlertContacts), ac.FriendlyName)})
+			}
+		}
+		switch {
+		case hasDiff(diffs, "Type"):
+			// The type of a monitor cannot be edited
+			plan.Monitors = append(plan.Monitors, MonitorChange{Action: ActionReplace, Key: key, Current: &cur, Desired: &d, Diffs: diffs})
+		case len(diffs) > 0:
+			plan.Monitors = append(plan.Monitors, MonitorChange{Action: ActionUpdate, Key: key, Current: &cur, Desired: &d, Diffs: diffs})
+		}
+	}
+
+	if opts.PruneMonitors {
+		for _, m := range monitors {
+			m := m
+			if key := opts.Key(m); !desiredMonitors[key] {
+				plan.Monitors = append(plan.Monitors, MonitorChange{Action: ActionDelete, Key: key, Current: &m})
+			}
+		}
+	}
+
+	if opts.PruneAlertContacts {
+		for _, c := range contacts {
+			c := c
+			if c.FriendlyName != "" && !desiredContacts[c.FriendlyName] {
+				plan.AlertContacts = append(plan.AlertContacts, AlertContactChange{Action: ActionDelete, Key: c.FriendlyName, Current: &c})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply executes the plan. Alert contacts are created first so monitors can
+// refer to them, deletions happen last. Apply stops at the first error.
+func (p *Plan) Apply(ctx context.Context, ur *uptimerobot.UptimeRobot) error {
+	for _, c := range p.AlertContacts {
+		if c.Action == ActionDelete {
+			continue
+		}
+
+		if c.Action == ActionReplace {
+			if err := ur.DeleteAlertContactContext(ctx, c.Current.ID); err != nil {
+				return fmt.Errorf("Unable to replace alert contact %q: %s", c.Key, err)
+			}
+		}
+
+		ac := *c.Desired
+		if c.Action == ActionUpdate {
+			ac.ID = c.Current.ID
+		}
+
+		created, err := ur.NewOrEditAlertContactContext(ctx, ac)
+		if err != nil {
+			return fmt.Errorf("Unable to %s alert contact %q: %s", c.Action, c.Key, err)
+		}
+		p.contactIDs[c.Key] = created.ID
+	}
+
+	for _, c := range p.Monitors {
+		if c.Action == ActionDelete {
+			continue
+		}
+
+		if c.Action == ActionReplace {
+			if err := ur.DeleteMonitorContext(ctx, c.Current.ID); err != nil {
+				return fmt.Errorf("Unable to replace monitor %q: %s", c.Key, err)
+			}
+		}
+
+		m := *c.Desired
+		m.AlertContacts = p.resolveContacts(m.AlertContacts)
+		if c.Action == ActionUpdate {
+			m.ID = c.Current.ID
+		}
+
+		if _, err := ur.NewOrEditMonitorContext(ctx, m); err != nil {
+			return fmt.Errorf("Unable to %s monitor %q: %s", c.Action, c.Key, err)
+		}
+	}
+
+	for _, c := range p.Monitors {
+		if c.Action != ActionDelete {
+			continue
+		}
+		if err := ur.DeleteMonitorContext(ctx, c.Current.ID); err != nil {
+			return fmt.Errorf("Unable to delete monitor %q: %s", c.Key, err)
+		}
+	}
+
+	for _, c := range p.AlertContacts {
+		if c.Action != ActionDelete {
+			continue
+		}
+		if err := ur.DeleteAlertContactContext(ctx, c.Current.ID); err != nil {
+			return fmt.Errorf("Unable to delete alert contact %q: %s", c.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveContacts fills in the IDs of alert contacts referenced by name
+func (p *Plan) resolveContacts(in []uptimerobot.AlertContact) []uptimerobot.AlertContact {
+	out := make([]uptimerobot.AlertContact, 0, len(in))
+	for _, ac := range in {
+		if ac.ID == 0 {
+			ac.ID = p.contactIDs[ac.FriendlyName]
+		}
+		out = append(out, ac)
+	}
+	return out
+}
+
+func diffAlertContact(cur, d uptimerobot.AlertContact) []FieldDiff {
+	diffs := []FieldDiff{}
+	if d.Type != 0 && d.Type != cur.Type {
+		diffs = append(diffs, FieldDiff{Field: "Type", Old: fmt.Sprint(cur.Type), New: fmt.Sprint(d.Type)})
+	}
+	if d.Value != "" && d.Value != cur.Value {
+		diffs = append(diffs, FieldDiff{Field: "Value", Old: fmt.Sprintf("%q", cur.Value), New: fmt.Sprintf("%q", d.Value)})
+	}
+	if d.Status != uptimerobot.AlertContactStatusNotActivated && d.Status != cur.Status {
+		diffs = append(diffs, FieldDiff{Field: "Status", Old: fmt.Sprint(cur.Status), New: fmt.Sprint(d.Status)})
+	}
+	return diffs
+}
+
+func diffMonitor(cur, d uptimerobot.Monitor, contactIDs map[string]int) []FieldDiff {
+	diffs := []FieldDiff{}
+	add := func(field string, changed bool, old, new interface{}) {
+		if changed {
+			diffs = append(diffs, FieldDiff{Field: field, Old: fmt.Sprintf("%#v", old), New: fmt.Sprintf("%#v", new)})
+		}
+	}
+
+	add("FriendlyName", d.FriendlyName != cur.FriendlyName, cur.FriendlyName, d.FriendlyName)
+	add("URL", d.URL != cur.URL, cur.URL, d.URL)
+	add("Type", d.Type != cur.Type, int(cur.Type), int(d.Type))
+	add("Subtype", d.Subtype != 0 && d.Subtype != cur.Subtype, int(cur.Subtype), int(d.Subtype))
+	add("Port", d.Port != 0 && d.Port != cur.Port, cur.Port, d.Port)
+	add("KeywordType", d.KeywordType != 0 && d.KeywordType != cur.KeywordType, int(cur.KeywordType), int(d.KeywordType))
+	add("KeywordValue", d.KeywordValue != "" && d.KeywordValue != cur.KeywordValue, cur.KeywordValue, d.KeywordValue)
+	add("HTTPUsername", d.HTTPUsername != "" && d.HTTPUsername != cur.HTTPUsername, cur.HTTPUsername, d.HTTPUsername)
+	if d.HTTPPassword != "" && d.HTTPPassword != cur.HTTPPassword {
+		diffs = append(diffs, FieldDiff{Field: "HTTPPassword", Old: "(sensitive)", New: "(sensitive)"})
+	}
+	add("Interval", d.Interval != 0 && d.Interval != cur.Interval, cur.Interval, d.Interval)
+
+	if len(d.AlertContacts) > 0 {
+		want := make([]uptimerobot.AlertContact, 0, len(d.AlertContacts))
+		for _, ac := range d.AlertContacts {
+			if ac.ID == 0 {
+				ac.ID = contactIDs[ac.FriendlyName]
+			}
+			want = append(want, ac)
+		}
+		if contactSpec(want) != contactSpec(cur.AlertContacts) {
+			diffs = append(diffs, FieldDiff{Field: "AlertContacts", Old: formatContacts(cur.AlertContacts), New: formatContacts(d.AlertContacts)})
+		}
+	}
+
+	return diffs
+}
+
+// contactSpec returns a canonical representation of the alert contact
+// assignments of a monitor for comparison
+func contactSpec(in []uptimerobot.AlertContact) string {
+	parts := []string{}
+	for _, ac := range in {
+		parts = append(parts, fmt.Sprintf("%d_%d_%d", ac.ID, ac.Threshold, ac.Recurrence))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "-")
+}
+
+func formatContacts(in []uptimerobot.AlertContact) string {
+	parts := []string{}
+	for _, ac := range in {
+		name := ac.FriendlyName
+		if name == "" {
+			name = fmt.Sprintf("#%d", ac.ID)
+		}
+		parts = append(parts, fmt.Sprintf("%s (threshold %d, recurrence %d)", name, ac.Threshold, ac.Recurrence))
+	}
+	sort.Strings(parts)
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func hasDiff(diffs []FieldDiff, field string) bool {
+	for _, d := range diffs {
+		if d.Field == field {
+			return true
+		}
+	}
+	return false
+}
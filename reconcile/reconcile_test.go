@@ -0,0 +1,173 @@
+package reconcile
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+func TestPlanAndApply(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+	ctx := context.Background()
+
+	oncall := srv.AddAlertContact(uptimerobot.AlertContact{
+		FriendlyName: "oncall",
+		Type:         uptimerobot.AlertContactTypeEMail,
+		Value:        "old@example.com",
+	})
+	hook := srv.AddAlertContact(uptimerobot.AlertContact{
+		FriendlyName: "hook",
+		Type:         uptimerobot.AlertContactTypeEMail,
+		Value:        "hooks@example.com",
+	})
+	srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName: "web",
+		URL:          "http://www.example.com/",
+		Type:         uptimerobot.MonitorTypeHTTP,
+		Interval:     300,
+	})
+	srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName: "legacy",
+		URL:          "http://legacy.example.com/",
+		Type:         uptimerobot.MonitorTypeHTTP,
+	})
+
+	desired := Desired{
+		AlertContacts: []uptimerobot.AlertContact{
+			{FriendlyName: "oncall", Type: uptimerobot.AlertContactTypeEMail, Value: "new@example.com"},
+			{FriendlyName: "hook", Type: uptimerobot.AlertContactTypeWebHook, Value: "https://hooks.example.com/?"},
+		},
+		Monitors: []uptimerobot.Monitor{
+			{
+				FriendlyName:  "web",
+				URL:           "https://www.example.com/",
+				Type:          uptimerobot.MonitorTypeHTTP,
+				Interval:      300,
+				AlertContacts: []uptimerobot.AlertContact{{FriendlyName: "oncall", Threshold: 0, Recurrence: 0}},
+			},
+			{
+				FriendlyName:  "api",
+				URL:           "https://api.example.com/",
+				Type:          uptimerobot.MonitorTypeHTTP,
+				AlertContacts: []uptimerobot.AlertContact{{FriendlyName: "hook", Threshold: 5, Recurrence: 30}},
+			},
+		},
+	}
+
+	plan, err := NewPlan(ctx, ur, desired, Options{PruneMonitors: true})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if create, update, del := plan.Counts(); create != 1 || update != 3 || del != 1 {
+		t.Errorf("Unexpected plan counts %d/%d/%d:\n%s", create, update, del, plan)
+	}
+
+	out := plan.String()
+	for _, want := range []string{`~ alert contact "oncall"`, `-/+ alert contact "hook"`, `~ monitor "web"`, `URL: "http://www.example.com/" => "https://www.example.com/"`, `+ monitor "api"`, `- monitor "legacy"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Plan does not contain %q:\n%s", want, out)
+		}
+	}
+
+	if err := plan.Apply(ctx, ur); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	contacts := map[string]uptimerobot.AlertContact{}
+	for _, c := range srv.AlertContacts() {
+		contacts[c.FriendlyName] = c
+	}
+	if contacts["oncall"].Value != "new@example.com" || contacts["oncall"].ID != oncall.ID {
+		t.Errorf("Alert contact was not edited in place: %+v", contacts["oncall"])
+	}
+	if contacts["hook"].Type != uptimerobot.AlertContactTypeWebHook || contacts["hook"].ID == hook.ID {
+		t.Errorf("Alert contact was not replaced: %+v", contacts["hook"])
+	}
+
+	monitors := srv.Monitors()
+	if len(monitors) != 2 {
+		t.Fatalf("Expected 2 monitors after apply, got %+v", monitors)
+	}
+	for _, m := range monitors {
+		want := map[string]int{"web": contacts["oncall"].ID, "api": contacts["hook"].ID}[m.FriendlyName]
+		if len(m.AlertContacts) != 1 || m.AlertContacts[0].ID != want {
+			t.Errorf("Monitor %q has unexpected alert contacts: %+v", m.FriendlyName, m.AlertContacts)
+		}
+	}
+
+	plan, err = NewPlan(ctx, ur, desired, Options{PruneMonitors: true})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if !plan.Empty() {
+		t.Errorf("Expected an empty plan after apply, got:\n%s", plan)
+	}
+}
+
+func TestPlanRejectsUnknownContacts(t *testing.T) {
+	_, err := planChanges(nil, nil, Desired{
+		Monitors: []uptimerobot.Monitor{{
+			FriendlyName:  "web",
+			URL:           "https://www.example.com/",
+			Type:          uptimerobot.MonitorTypeHTTP,
+			AlertContacts: []uptimerobot.AlertContact{{FriendlyName: "nobody"}},
+		}},
+	}, Options{Key: KeyURL})
+
+	if err == nil || !strings.Contains(err.Error(), "unknown alert contact") {
+		t.Errorf("Expected an unknown alert contact error, got: %v", err)
+	}
+}
+
+func TestApplyReplacesMonitorOnTypeChange(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+	ctx := context.Background()
+
+	old := srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName: "web",
+		URL:          "www.example.com",
+		Type:         uptimerobot.MonitorTypeHTTP,
+	})
+
+	desired := Desired{
+		Monitors: []uptimerobot.Monitor{{
+			FriendlyName: "web",
+			URL:          "www.example.com",
+			Type:         uptimerobot.MonitorTypePing,
+		}},
+	}
+
+	plan, err := NewPlan(ctx, ur, desired, Options{})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(plan.Monitors) != 1 || plan.Monitors[0].Action != ActionReplace {
+		t.Fatalf("Expected the monitor to be replaced, got:\n%s", plan)
+	}
+
+	if err := plan.Apply(ctx, ur); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	monitors := srv.Monitors()
+	if len(monitors) != 1 || monitors[0].Type != uptimerobot.MonitorTypePing || monitors[0].ID == old.ID {
+		t.Errorf("Monitor was not replaced: %+v", monitors)
+	}
+
+	plan, err = NewPlan(ctx, ur, desired, Options{})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Expected an empty plan after apply, got:\n%s", plan)
+	}
+}
@@ -0,0 +1,50 @@
+package uptimerobot
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Jimdo/uptimerobot-api/internal/redact"
+)
+
+// redacted replaces secrets in debug output
+const redacted = redact.Redacted
+
+// redactValues returns a copy of the parameters with the values of secret
+// parameters replaced
+func redactValues(params url.Values) url.Values {
+	return redact.Values(params)
+}
+
+// redactParams encodes the parameters like url.Values.Encode with the values
+// of secret parameters replaced
+func redactParams(params url.Values) string {
+	return redactValues(params).Encode()
+}
+
+// redactBody replaces the values of secret fields in a JSON document
+func redactBody(body []byte) string {
+	return redact.Body(body, false)
+}
+
+// String formats the monitor like the %+v verb with the HTTPPassword masked,
+// so monitors can be logged safely
+func (m Monitor) String() string {
+	type monitor Monitor
+	return fmt.Sprintf("%+v", monitor(m.redacted()))
+}
+
+// GoString is like String for the %#v verb
+func (m Monitor) GoString() string {
+	type monitor Monitor
+	s := fmt.Sprintf("%#v", monitor(m.redacted()))
+	return "uptimerobot.Monitor" + s[strings.Index(s, "{"):]
+}
+
+func (m Monitor) redacted() Monitor {
+	if m.HTTPPassword != "" {
+		m.HTTPPassword = redacted
+	}
+	return m
+}
@@ -0,0 +1,53 @@
+package uptimerobot
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugOutputRedactsSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":1},"monitors":[{"id":1,"friendly_name":"web","http_username":"admin","http_password":"s3cr\"et"}]}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+
+	ur := New("u1234-topsecret")
+	ur.BaseURL = srv.URL
+	ur.FullDebug = true
+
+	mons, err := ur.GetMonitors(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "topsecret") || strings.Contains(out, "s3cr") {
+		t.Errorf("Debug output contains secrets: %s", out)
+	}
+	if !strings.Contains(out, `"http_username":"admin"`) || !strings.Contains(out, "api_key="+redacted) {
+		t.Errorf("Debug output is missing the redacted request or response: %s", out)
+	}
+
+	if len(mons) != 1 || mons[0].HTTPPassword != `s3cr"et` {
+		t.Fatalf("Unexpected monitors: %+v", mons)
+	}
+
+	for _, s := range []string{mons[0].String(), fmt.Sprint(mons[0]), fmt.Sprintf("%+v", mons[0]), fmt.Sprintf("%#v", mons[0])} {
+		if strings.Contains(s, "s3cr") || !strings.Contains(s, "web") {
+			t.Errorf("Unexpected formatted monitor: %s", s)
+		}
+	}
+
+	if s := fmt.Sprintf("%#v", mons[0]); !strings.HasPrefix(s, "uptimerobot.Monitor{") {
+		t.Errorf("Unexpected Go syntax representation: %s", s)
+	}
+}
@@ -0,0 +1,96 @@
+package responsetime
+
+import (
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Anomaly is a period in which the rolling average of the response times of a
+// monitor shifted away from its baseline
+type Anomaly struct {
+	// the ID of the monitor (only set by DetectMonitors)
+	MonitorID int
+	// the first and last point whose rolling average exceeded the threshold
+	Start time.Time
+	End   time.Time
+	// the highest rolling average within the anomaly and the baseline median
+	// it was compared to
+	Peak     time.Duration
+	Baseline time.Duration
+}
+
+// Detector flags sudden latency shifts: periods in which the rolling average
+// of the response times exceeds the median of a baseline by Factor and at
+// least MinIncrease
+type Detector struct {
+	// the window of the rolling average, which smooths out single slow checks
+	Window time.Duration
+	// the factor of the baseline median a rolling average has to exceed
+	Factor float64
+	// the minimum increase over the baseline median, which keeps fast
+	// monitors from being flagged for irrelevant changes
+	MinIncrease time.Duration
+}
+
+// DefaultDetector flags rolling averages over 30 minutes exceeding twice the
+// baseline median by at least 100ms
+func DefaultDetector() Detector {
+	return Detector{
+		Window:      30 * time.Minute,
+		Factor:      2,
+		MinIncrease: 100 * time.Millisecond,
+	}
+}
+
+// Detect returns the anomalies of the response times compared to the
+// baseline, for example the summary of the previous week. A zero baseline is
+// replaced by the summary of the response times themselves.
+func (d Detector) Detect(points []Point, baseline Summary) []Anomaly {
+	if baseline.Count == 0 {
+		baseline = SummarizePoints(points)
+	}
+
+	threshold := time.Duration(float64(baseline.P50) * d.Factor)
+	if min := baseline.P50 + d.MinIncrease; threshold < min {
+		threshold = min
+	}
+
+	anomalies := []Anomaly{}
+	var current *Anomaly
+	for _, avg := range RollingAverage(points, d.Window) {
+		if avg.Value <= threshold {
+			if current != nil {
+				anomalies = append(anomalies, *current)
+				current = nil
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &Anomaly{Start: avg.Time, Baseline: baseline.P50}
+		}
+		current.End = avg.Time
+		if avg.Value > current.Peak {
+			current.Peak = avg.Value
+		}
+	}
+	if current != nil {
+		anomalies = append(anomalies, *current)
+	}
+	return anomalies
+}
+
+// DetectMonitors returns the anomalies of all monitors. The baselines are
+// looked up by monitor ID, monitors without one are compared to their own
+// response times.
+func (d Detector) DetectMonitors(monitors []uptimerobot.Monitor, baselines map[int]Summary) []Anomaly {
+	anomalies := []Anomaly{}
+	for _, m := range monitors {
+		for _, a := range d.Detect(Points(m.ResponseTimes), baselines[m.ID]) {
+			a.MonitorID = m.ID
+			anomalies = append(anomalies, a)
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,169 @@
+// Package responsetime analyzes the response times of monitors: percentiles,
+// aggregates per time bucket, rolling averages and sudden latency shifts
+// against a baseline.
+//
+//	mons, err := ur.GetMonitors(&uptimerobot.GetMonitorsInput{ResponseTimes: true})
+//	s := responsetime.Summarize(mons[0].ResponseTimes)
+//	fmt.Printf("p50 %s, p99 %s", s.P50, s.P99)
+//
+//	for _, a := range responsetime.DefaultDetector().DetectMonitors(mons, nil) {
+//		fmt.Printf("monitor %d slow from %s to %s", a.MonitorID, a.Start, a.End)
+//	}
+package responsetime
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Point is a response time at a point in time
+type Point struct {
+	Time  time.Time
+	Value time.Duration
+}
+
+// Points converts the response times returned by the API (in milliseconds)
+// and orders them by time
+func Points(rts []uptimerobot.ResponseTime) []Point {
+	points := make([]Point, 0, len(rts))
+	for _, rt := range rts {
+		points = append(points, Point{
+			Time:  time.Time(rt.DateTime),
+			Value: time.Duration(rt.Value) * time.Millisecond,
+		})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	return points
+}
+
+// Summary describes the distribution of response times
+type Summary struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	// the percentiles by the nearest-rank method
+	P50 time.Duration
+	P90 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+}
+
+// Summarize describes the response times of a monitor
+func Summarize(rts []uptimerobot.ResponseTime) Summary {
+	return SummarizePoints(Points(rts))
+}
+
+// SummarizeMonitors describes the response times of all monitors together
+func SummarizeMonitors(monitors []uptimerobot.Monitor) Summary {
+	points := []Point{}
+	for _, m := range monitors {
+		points = append(points, Points(m.ResponseTimes)...)
+	}
+	return SummarizePoints(points)
+}
+
+// SummarizePoints describes the response times, the zero Summary is returned
+// for no points
+func SummarizePoints(points []Point) Summary {
+	if len(points) == 0 {
+		return Summary{}
+	}
+
+	values := make([]time.Duration, 0, len(points))
+	var sum time.Duration
+	for _, p := range points {
+		values = append(values, p.Value)
+		sum += p.Value
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	return Summary{
+		Count: len(values),
+		Min:   values[0],
+		Max:   values[len(values)-1],
+		Mean:  sum / time.Duration(len(values)),
+		P50:   percentile(values, 50),
+		P90:   percentile(values, 90),
+		P95:   percentile(values, 95),
+		P99:   percentile(values, 99),
+	}
+}
+
+// Percentile returns the p-th percentile (0 < p <= 100) of the response times
+func Percentile(points []Point, p float64) time.Duration {
+	values := make([]time.Duration, 0, len(points))
+	for _, pt := range points {
+		values = append(values, pt.Value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return percentile(values, p)
+}
+
+// percentile returns the p-th percentile of the sorted values
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Bucket summarizes the response times from Start to Start plus the bucket
+// size
+type Bucket struct {
+	Start time.Time
+	Summary
+}
+
+// Buckets groups the response times into buckets of the given size aligned
+// to the zero time (full hours for time.Hour), buckets without response
+// times are left out
+func Buckets(points []Point, size time.Duration) []Bucket {
+	grouped := map[time.Time][]Point{}
+	starts := []time.Time{}
+	for _, p := range points {
+		start := p.Time.Truncate(size)
+		if _, ok := grouped[start]; !ok {
+			starts = append(starts, start)
+		}
+		grouped[start] = append(grouped[start], p)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	buckets := make([]Bucket, 0, len(starts))
+	for _, start := range starts {
+		buckets = append(buckets, Bucket{Start: start, Summary: SummarizePoints(grouped[start])})
+	}
+	return buckets
+}
+
+// RollingAverage returns the average of the response times within the window
+// ending at each point, the window always contains the point itself
+func RollingAverage(points []Point, window time.Duration) []Point {
+	sorted := append([]Point{}, points...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	averages := make([]Point, 0, len(sorted))
+	var sum time.Duration
+	first := 0
+	for i, p := range sorted {
+		sum += p.Value
+		for first < i && !sorted[first].Time.After(p.Time.Add(-window)) {
+			sum -= sorted[first].Value
+			first++
+		}
+		averages = append(averages, Point{Time: p.Time, Value: sum / time.Duration(i+1-first)})
+	}
+	return averages
+}
@@ -0,0 +1,123 @@
+package responsetime
+
+import (
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+var start = time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+// series returns response times in milliseconds one minute apart
+func series(values ...int) []uptimerobot.ResponseTime {
+	rts := []uptimerobot.ResponseTime{}
+	for i, v := range values {
+		rts = append(rts, uptimerobot.ResponseTime{
+			DateTime: uptimerobot.UptimeRobotDate(start.Add(time.Duration(i) * time.Minute)),
+			Value:    v,
+		})
+	}
+	// Newest first like the API returns them
+	for i, j := 0, len(rts)-1; i < j; i, j = i+1, j-1 {
+		rts[i], rts[j] = rts[j], rts[i]
+	}
+	return rts
+}
+
+func ms(v int) time.Duration {
+	return time.Duration(v) * time.Millisecond
+}
+
+func TestSummarize(t *testing.T) {
+	values := []int{}
+	for i := 1; i <= 100; i++ {
+		values = append(values, i*10)
+	}
+
+	s := Summarize(series(values...))
+	expected := Summary{Count: 100, Min: ms(10), Max: ms(1000), Mean: ms(505), P50: ms(500), P90: ms(900), P95: ms(950), P99: ms(990)}
+	if s != expected {
+		t.Errorf("Expected %+v, got %+v", expected, s)
+	}
+
+	if s := Summarize(nil); s != (Summary{}) {
+		t.Errorf("Expected an empty summary, got %+v", s)
+	}
+
+	if p := Percentile(Points(series(300, 100, 200)), 50); p != ms(200) {
+		t.Errorf("Expected a median of 200ms, got %s", p)
+	}
+
+	g := SummarizeMonitors([]uptimerobot.Monitor{
+		{ID: 1, ResponseTimes: series(100, 200)},
+		{ID: 2, ResponseTimes: series(300, 400)},
+	})
+	if g.Count != 4 || g.Min != ms(100) || g.Max != ms(400) || g.P50 != ms(200) {
+		t.Errorf("Unexpected group summary: %+v", g)
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	points := Points(series(100, 200, 300, 400, 500, 600, 700))
+
+	buckets := Buckets(points, 5*time.Minute)
+	if len(buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %+v", buckets)
+	}
+	if b := buckets[0]; !b.Start.Equal(start) || b.Count != 5 || b.Mean != ms(300) || b.Max != ms(500) {
+		t.Errorf("Unexpected bucket: %+v", b)
+	}
+	if b := buckets[1]; !b.Start.Equal(start.Add(5*time.Minute)) || b.Count != 2 || b.Min != ms(600) {
+		t.Errorf("Unexpected bucket: %+v", b)
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	avgs := RollingAverage(Points(series(100, 200, 300, 400)), 2*time.Minute)
+
+	expected := []time.Duration{ms(100), ms(150), ms(250), ms(350)}
+	if len(avgs) != len(expected) {
+		t.Fatalf("Expected %d averages, got %+v", len(expected), avgs)
+	}
+	for i, e := range expected {
+		if avgs[i].Value != e || !avgs[i].Time.Equal(start.Add(time.Duration(i)*time.Minute)) {
+			t.Errorf("Expected %s at %d, got %+v", e, i, avgs[i])
+		}
+	}
+
+	if avgs := RollingAverage(Points(series(100, 300)), 0); avgs[1].Value != ms(300) {
+		t.Errorf("Expected an empty window to contain the point, got %+v", avgs)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	d := Detector{Window: 3 * time.Minute, Factor: 2, MinIncrease: 50 * time.Millisecond}
+
+	// A single slow check is smoothed out, the shift at minute 10 is flagged
+	// until the averages recover
+	values := []int{100, 110, 90, 100, 400, 100, 95, 105, 100, 100, 500, 600, 550, 100, 100, 100, 100}
+	anomalies := d.Detect(Points(series(values...)), Summary{})
+
+	if len(anomalies) != 1 {
+		t.Fatalf("Expected 1 anomaly, got %+v", anomalies)
+	}
+	a := anomalies[0]
+	if !a.Start.Equal(start.Add(10*time.Minute)) || !a.End.Equal(start.Add(14*time.Minute)) || a.Peak != ms(550) || a.Baseline != ms(100) {
+		t.Errorf("Unexpected anomaly: %+v", a)
+	}
+
+	// Fast monitors doubling their response time stay below MinIncrease
+	if anomalies := d.Detect(Points(series(10, 10, 10, 30, 30, 30)), Summary{Count: 1, P50: ms(10)}); len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies, got %+v", anomalies)
+	}
+
+	monitors := []uptimerobot.Monitor{
+		{ID: 1, ResponseTimes: series(values...)},
+		{ID: 2, ResponseTimes: series(300, 300, 300, 300)},
+	}
+	anomalies = d.DetectMonitors(monitors, map[int]Summary{2: {Count: 10, P50: ms(100)}})
+	if len(anomalies) != 2 || anomalies[0].MonitorID != 1 || anomalies[1].MonitorID != 2 || anomalies[1].Baseline != ms(100) {
+		t.Errorf("Unexpected anomalies: %+v", anomalies)
+	}
+}
@@ -0,0 +1,110 @@
+package uptimerobot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// nonIdempotentMethods lists the API methods which create resources and
+// therefore must not be repeated without the caller opting in
+var nonIdempotentMethods = map[string]bool{
+	"newMonitor":      true,
+	"newAlertContact": true,
+	"newMWindow":      true,
+	"newPSP":          true,
+}
+
+// RetryPolicy controls whether and when a failed API request is repeated
+type RetryPolicy struct {
+	// the maximum number of attempts including the first one (values below 2
+	// disable retries)
+	MaxAttempts int
+	// returns the time to wait before the given retry (1 for the first retry)
+	Backoff func(retry int) time.Duration
+	// decides whether the error returned for the given API method is worth
+	// another attempt (defaults to IsRetryable if not set)
+	Retryable func(apiMethod string, err error) bool
+}
+
+// DefaultRetryPolicy returns the policy used by clients created with New: up
+// to three attempts with exponential backoff starting at 500ms and capped at
+// 10s, retrying only errors accepted by IsRetryable
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff(500*time.Millisecond, 10*time.Second),
+		Retryable:   IsRetryable,
+	}
+}
+
+// ExponentialBackoff returns a backoff function doubling the wait time for
+// every retry, starting at base and never exceeding max. The returned duration
+// is randomized between zero and the computed value ("full jitter") to keep
+// several clients from retrying in lockstep.
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		d := base
+		for i := 1; i < retry && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		if d <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(d) + 1))
+	}
+}
+
+// IsRetryable reports whether err is a transient failure (server errors, rate
+// limiting, timeouts and dropped connections) of an API method which can
+// safely be repeated. Methods creating resources (newMonitor, newAlertContact,
+// newMWindow, newPSP) are never considered retryable.
+func IsRetryable(apiMethod string, err error) bool {
+	if nonIdempotentMethods[apiMethod] {
+		return false
+	}
+	return isTransientError(err)
+}
+
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package uptimerobot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int, body string) (*httptest.Server, *int) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	return srv, &calls
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	srv, calls := newFlakyServer(2, `{"stat":"ok","account":{"monitor_limit":50}}`)
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL
+	ur.RetryPolicy.Backoff = func(int) time.Duration { return 0 }
+
+	ad, err := ur.GetAccountDetails()
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+
+	if ad.MonitorLimit != 50 {
+		t.Errorf("Unexpected account details: %+v", ad)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, calls := newFlakyServer(5, `{"stat":"ok"}`)
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL
+	ur.RetryPolicy.Backoff = func(int) time.Duration { return 0 }
+
+	_, err := ur.GetAccountDetails()
+	if err == nil {
+		t.Fatalf("Test should have errored.")
+	}
+
+	if *calls != ur.RetryPolicy.MaxAttempts {
+		t.Errorf("Expected %d attempts, got %d", ur.RetryPolicy.MaxAttempts, *calls)
+	}
+}
+
+func TestRetrySkipsNonIdempotentMethods(t *testing.T) {
+	srv, calls := newFlakyServer(1, `{"stat":"ok","alertcontact":{"id":1,"status":0}}`)
+	defer srv.Close()
+
+	ur := New("foobar")
+	ur.BaseURL = srv.URL
+	ur.RetryPolicy.Backoff = func(int) time.Duration { return 0 }
+
+	_, err := ur.NewAlertContact(AlertContact{Type: AlertContactTypeEMail, Value: "foo@example.com"})
+	if err == nil {
+		t.Fatalf("Test should have errored.")
+	}
+
+	if *calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", *calls)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for retry := 1; retry < 10; retry++ {
+		if d := b(retry); d < 0 || d > time.Second {
+			t.Errorf("Backoff for retry %d out of bounds: %s", retry, d)
+		}
+	}
+}
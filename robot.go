@@ -1,23 +1,56 @@
 package uptimerobot
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/Jimdo/uptimerobot-api/internal/redact"
 )
 
+// DefaultBaseURL is the address of the public UptimeRobot API (v2) used by
+// clients created with New
+const DefaultBaseURL = "https://api.uptimerobot.com/v2"
+
 // UptimeRobot is a representation of the UptimeRobot public API
 type UptimeRobot struct {
-	apikey         string
-	HTTPClient     *http.Client
-	FullDebug      bool
+	apikey     string
+	HTTPClient *http.Client
+	// FullDebug logs the raw requests and responses with secrets redacted
+	// (to the standard logger if no Logger is set)
+	FullDebug bool
+	// Logger receives a structured event for every API call (nil disables
+	// the events unless FullDebug is set)
+	Logger Logger
+	// Middlewares wrap every API call, the first one is the outermost (see
+	// Middleware)
+	Middlewares []Middleware
+	// DryRun keeps calls changing the account from being sent: they are
+	// validated, logged and answered with a synthetic result while reading
+	// calls still reach the API (see Mutations)
+	DryRun bool
+	// BaseURL is the scheme, host and optional path prefix every API method
+	// is appended to (Example: "http://localhost:8080/uptimerobot")
+	BaseURL string
+	// RetryPolicy controls the repetition of failed requests (nil disables
+	// retries)
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles the requests of this client (nil disables the
+	// client side rate limit). Use SharedRateLimiter to share it between
+	// clients using the same API-key.
+	RateLimiter    *RateLimiter
 	disableCaching bool
+
+	mu        sync.Mutex
+	mutations []Mutation
 }
 
 // New creates a new UptimeRobot API client with the given API-key to identify
@@ -27,61 +60,197 @@ func New(apikey string) *UptimeRobot {
 		apikey:         apikey,
 		HTTPClient:     http.DefaultClient,
 		FullDebug:      false,
+		BaseURL:        DefaultBaseURL,
+		RetryPolicy:    DefaultRetryPolicy(),
 		disableCaching: false,
 	}
 }
 
-func (u *UptimeRobot) doRequest(apiMethod string, params *url.Values, target interface{}) error {
+func (u *UptimeRobot) doRequest(ctx context.Context, apiMethod string, params *url.Values, target interface{}) error {
 	if params == nil {
 		params = &url.Values{}
 	}
 
-	params.Set("noJsonCallback", "1") // Enforce not to get JSONP wrapper
 	params.Set("format", "json")
-	params.Set("apiKey", u.apikey)
+	params.Set("api_key", u.apikey)
 
 	if u.FullDebug || u.disableCaching {
 		params.Set("v", strconv.FormatInt(time.Now().UnixNano(), 10))
 	}
 
-	url := url.URL{
-		Scheme:   "https",
-		Host:     "api.uptimerobot.com",
-		Path:     fmt.Sprintf("/%s", apiMethod),
-		RawQuery: params.Encode(),
+	call := &Call{Method: apiMethod, Params: *params}
+
+	var h Handler = u.send
+	if u.DryRun {
+		h = u.dryRunMiddleware(ctx)(h)
+	}
+	for i := len(u.Middlewares) - 1; i >= 0; i-- {
+		h = u.Middlewares[i](h)
+	}
+
+	if err := h(ctx, call); err != nil {
+		return err
+	}
+
+	// A middleware may short-circuit the call without a response
+	if len(call.Response) == 0 {
+		return nil
+	}
+	return json.Unmarshal(call.Response, target)
+}
+
+// send is the innermost handler of the middleware chain: it performs the
+// call including retries and records the outcome in the call
+func (u *UptimeRobot) send(ctx context.Context, call *Call) error {
+	start := time.Now()
+	defer func() {
+		call.Duration = time.Since(start)
+	}()
+
+	policy := u.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		attemptStart := time.Now()
+		res, err := u.doAttempt(ctx, call.Method, &call.Params)
+		u.logAttempt(ctx, call.Method, &call.Params, attempt, time.Since(attemptStart), err)
+
+		call.Attempts = attempt
+		call.Response = res
+		call.Stat, call.ErrorCode = "ok", 0
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			call.Stat, call.ErrorCode = apiErr.Stat, apiErr.Code
+		}
+
+		if err == nil || policy == nil || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		retryable := policy.Retryable
+		if retryable == nil {
+			retryable = IsRetryable
+		}
+		if !retryable(call.Method, err) {
+			return err
+		}
+
+		var wait time.Duration
+		if policy.Backoff != nil {
+			wait = policy.Backoff(attempt)
+		}
+
+		if l := u.logger(); l != nil {
+			l.WarnContext(ctx, "Retrying UptimeRobot API call", "method", call.Method, "attempt", attempt, "wait", wait)
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// doAttempt sends a single request and returns the response body if the API
+// reported success
+func (u *UptimeRobot) doAttempt(ctx context.Context, apiMethod string, params *url.Values) ([]byte, error) {
+	if u.RateLimiter != nil {
+		if err := u.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	baseURL := u.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
 	}
 
+	url, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid BaseURL: %s", err)
+	}
+	url.Path = fmt.Sprintf("%s/%s", strings.TrimSuffix(url.Path, "/"), apiMethod)
+
+	// The parameters are sent as form body so the API-key and passwords do
+	// not end up in access logs
+	body := params.Encode()
 	if u.FullDebug {
-		log.Printf("[DEBUG] => %s\n", url.String())
+		u.logger().DebugContext(ctx, "UptimeRobot API request", "url", url.String(), "body", redactParams(*params))
 	}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), strings.NewReader(body))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	res, err := u.HTTPClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
+	resBody, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if u.FullDebug {
-		log.Printf("[DEBUG] <= %s\n", string(body))
+		u.logger().DebugContext(ctx, "UptimeRobot API response", "method", apiMethod, "body", redactBody(resBody))
+	}
+
+	status := &struct {
+		Stat  string `json:"stat"`
+		Error struct {
+			Type          string          `json:"type"`
+			ParameterName string          `json:"parameter_name"`
+			PassedValue   json.RawMessage `json:"passed_value"`
+			Message       string          `json:"message"`
+		} `json:"error"`
+	}{}
+
+	if err := json.Unmarshal(resBody, status); err != nil {
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, &Error{Method: apiMethod, StatusCode: res.StatusCode}
+		}
+		return nil, err
 	}
 
-	err = json.Unmarshal(body, target)
-	return err
+	if status.Stat != "ok" || res.StatusCode < 200 || res.StatusCode > 299 {
+		e := status.Error
+		passedValue := rawString(e.PassedValue)
+		if redact.IsSecret(e.ParameterName) && passedValue != "" {
+			passedValue = redacted
+		}
+		return nil, &Error{
+			Method:        apiMethod,
+			StatusCode:    res.StatusCode,
+			Stat:          status.Stat,
+			Code:          errorCode(apiMethod, e.Type, e.ParameterName),
+			Type:          e.Type,
+			ParameterName: e.ParameterName,
+			PassedValue:   passedValue,
+			Message:       e.Message,
+		}
+	}
+
+	return resBody, nil
 }
 
 func (u *UptimeRobot) buildIntList(in interface{}) string {
+	ints := []int{}
+	switch v := in.(type) {
+	case []int:
+		ints = v
+	case []MonitorType:
+		for _, i := range v {
+			ints = append(ints, int(i))
+		}
+	case []MonitorStatus:
+		for _, i := range v {
+			ints = append(ints, int(i))
+		}
+	}
+
 	m := []string{}
-	for _, i := range in.([]int) {
+	for _, i := range ints {
 		m = append(m, strconv.FormatInt(int64(i), 10))
 	}
 	return strings.Join(m, "-")
@@ -0,0 +1,155 @@
+package sla
+
+import (
+	"sort"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Incident is an outage of a monitor from a down log to the next log changing
+// its state, usually the up log
+type Incident struct {
+	// the ID of the monitor (only set by MonitorIncidents)
+	MonitorID int
+	Start     time.Time
+	// the end of the outage, zero while it is ongoing
+	End     time.Time
+	Ongoing bool
+	// the time the monitor was down, until Now for ongoing incidents
+	Duration time.Duration
+	// the cause reported by the down log (Example: Code "404", Detail "Not
+	// Found")
+	Reason uptimerobot.LogReason
+	// the alert contacts notified about the outage and the recovery, the logs
+	// have to be fetched with GetMonitorsInput.LogAlertContacts
+	AlertContacts []uptimerobot.AlertContact
+}
+
+// Incidents returns the incidents of the logs of a monitor ordered by their
+// start, ongoing incidents last until now
+func Incidents(logs []uptimerobot.Log, now time.Time) []Incident {
+	sorted := append([]uptimerobot.Log{}, logs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return time.Time(sorted[i].DateTime).Before(time.Time(sorted[j].DateTime))
+	})
+
+	incidents := []Incident{}
+	var current *Incident
+	for _, l := range sorted {
+		t := time.Time(l.DateTime)
+
+		if l.Type == uptimerobot.LogTypeDown {
+			// Further down logs, like a new reason, continue the incident
+			if current == nil {
+				current = &Incident{Start: t, Reason: l.Reason}
+			}
+			current.AlertContacts = addAlertContacts(current.AlertContacts, l.AlertContacts)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		current.End = t
+		current.Duration = t.Sub(current.Start)
+		if l.Type == uptimerobot.LogTypeUp {
+			current.AlertContacts = addAlertContacts(current.AlertContacts, l.AlertContacts)
+		}
+		incidents = append(incidents, *current)
+		current = nil
+	}
+
+	if current != nil {
+		current.Ongoing = true
+		current.Duration = now.Sub(current.Start)
+		incidents = append(incidents, *current)
+	}
+	return incidents
+}
+
+// MonitorIncidents returns the incidents of the monitor, it has to be fetched
+// with GetMonitorsInput.Logs
+func MonitorIncidents(m uptimerobot.Monitor, now time.Time) []Incident {
+	incidents := Incidents(m.Logs, now)
+	for i := range incidents {
+		incidents[i].MonitorID = m.ID
+	}
+	return incidents
+}
+
+func addAlertContacts(contacts, add []uptimerobot.AlertContact) []uptimerobot.AlertContact {
+	for _, c := range add {
+		known := false
+		for _, k := range contacts {
+			known = known || k.ID == c.ID
+		}
+		if !known {
+			contacts = append(contacts, c)
+		}
+	}
+	return contacts
+}
+
+// Reliability summarizes the incidents of one or more monitors within a
+// period
+type Reliability struct {
+	// the incidents overlapping the period
+	Incidents []Incident
+	// the time the monitors were up and down within the period (see Report)
+	Up   time.Duration
+	Down time.Duration
+	// the mean time to recovery: the average duration of the resolved
+	// incidents, 0 without any
+	MTTR time.Duration
+	// the mean time between failures: the time up divided by the number of
+	// incidents, 0 without any
+	MTBF time.Duration
+}
+
+// MonitorReliability summarizes the incidents of the monitor within p, it has
+// to be fetched with GetMonitorsInput.Logs
+func MonitorReliability(m uptimerobot.Monitor, p Period, now time.Time) Reliability {
+	return GroupReliability([]uptimerobot.Monitor{m}, p, now)
+}
+
+// GroupReliability summarizes the incidents of all monitors within p, the
+// monitors have to be fetched with GetMonitorsInput.Logs
+func GroupReliability(monitors []uptimerobot.Monitor, p Period, now time.Time) Reliability {
+	r := Reliability{Incidents: []Incident{}}
+	for _, m := range monitors {
+		for _, i := range MonitorIncidents(m, now) {
+			end := i.End
+			if i.Ongoing {
+				end = now
+			}
+			if i.Start.Before(p.End) && end.After(p.Start) {
+				r.Incidents = append(r.Incidents, i)
+			}
+		}
+
+		report := Calculate(m.Logs, p, Options{Now: now})
+		r.Up += report.Up
+		r.Down += report.Down
+	}
+
+	sort.SliceStable(r.Incidents, func(i, j int) bool {
+		return r.Incidents[i].Start.Before(r.Incidents[j].Start)
+	})
+
+	var repaired time.Duration
+	resolved := 0
+	for _, i := range r.Incidents {
+		if !i.Ongoing {
+			repaired += i.Duration
+			resolved++
+		}
+	}
+	if resolved > 0 {
+		r.MTTR = repaired / time.Duration(resolved)
+	}
+	if len(r.Incidents) > 0 {
+		r.MTBF = r.Up / time.Duration(len(r.Incidents))
+	}
+	return r
+}
@@ -0,0 +1,89 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func TestIncidents(t *testing.T) {
+	oncall := uptimerobot.AlertContact{ID: 1, Value: "oncall@example.com"}
+	chat := uptimerobot.AlertContact{ID: 2, Value: "https://chat.example.com/hook"}
+
+	down := logAt(uptimerobot.LogTypeDown, "2024-03-01 10:00")
+	down.Reason = uptimerobot.LogReason{Code: "503", Detail: "Service Unavailable"}
+	down.AlertContacts = []uptimerobot.AlertContact{oncall}
+	up := logAt(uptimerobot.LogTypeUp, "2024-03-01 10:30")
+	up.AlertContacts = []uptimerobot.AlertContact{oncall, chat}
+
+	now := at("2024-03-03 00:00")
+	incidents := Incidents([]uptimerobot.Log{
+		logAt(uptimerobot.LogTypeDown, "2024-03-02 23:00"),
+		logAt(uptimerobot.LogTypeStarted, "2024-03-02 00:00"),
+		logAt(uptimerobot.LogTypePaused, "2024-03-01 20:00"),
+		logAt(uptimerobot.LogTypeDown, "2024-03-01 19:00"),
+		up,
+		logAt(uptimerobot.LogTypeDown, "2024-03-01 10:10"),
+		down,
+		logAt(uptimerobot.LogTypeStarted, "2024-03-01 00:00"),
+	}, now)
+
+	if len(incidents) != 3 {
+		t.Fatalf("Expected 3 incidents, got %+v", incidents)
+	}
+
+	first := incidents[0]
+	if !first.Start.Equal(at("2024-03-01 10:00")) || !first.End.Equal(at("2024-03-01 10:30")) || first.Duration != 30*time.Minute || first.Ongoing {
+		t.Errorf("Unexpected incident: %+v", first)
+	}
+	if first.Reason.Code != "503" || len(first.AlertContacts) != 2 {
+		t.Errorf("Expected the reason and both notified alert contacts, got %+v", first)
+	}
+
+	// Pausing the monitor ends the incident
+	if paused := incidents[1]; paused.Duration != time.Hour || paused.Ongoing {
+		t.Errorf("Unexpected incident: %+v", paused)
+	}
+
+	if ongoing := incidents[2]; !ongoing.Ongoing || !ongoing.End.IsZero() || ongoing.Duration != time.Hour {
+		t.Errorf("Unexpected incident: %+v", ongoing)
+	}
+}
+
+func TestReliability(t *testing.T) {
+	now := at("2024-04-10 00:00")
+	march := Month(2024, time.March, time.UTC)
+
+	web := uptimerobot.Monitor{ID: 1, Logs: logs}
+	r := MonitorReliability(web, march, now)
+
+	if len(r.Incidents) != 2 || r.Incidents[0].MonitorID != 1 {
+		t.Fatalf("Expected 2 incidents in March, got %+v", r.Incidents)
+	}
+	if r.MTTR != 90*time.Minute {
+		t.Errorf("Expected a MTTR of 90m, got %s", r.MTTR)
+	}
+	if r.MTBF != r.Up/2 || r.Up != 717*time.Hour {
+		t.Errorf("Unexpected MTBF %s with %s up", r.MTBF, r.Up)
+	}
+
+	api := uptimerobot.Monitor{ID: 2, Logs: []uptimerobot.Log{
+		logAt(uptimerobot.LogTypeDown, "2024-04-09 12:00"),
+		logAt(uptimerobot.LogTypeUp, "2024-03-31 20:00"),
+		logAt(uptimerobot.LogTypeDown, "2024-03-31 18:00"),
+		logAt(uptimerobot.LogTypeStarted, "2024-02-01 00:00"),
+	}}
+
+	g := GroupReliability([]uptimerobot.Monitor{web, api}, march, now)
+	if len(g.Incidents) != 3 || g.Incidents[2].MonitorID != 2 {
+		t.Fatalf("Expected 3 incidents ordered by start, got %+v", g.Incidents)
+	}
+	if g.MTTR != 100*time.Minute || g.Up != 717*time.Hour+742*time.Hour || g.MTBF != g.Up/3 {
+		t.Errorf("Unexpected reliability: %+v", g)
+	}
+
+	if empty := GroupReliability(nil, march, now); empty.MTTR != 0 || empty.MTBF != 0 || len(empty.Incidents) != 0 {
+		t.Errorf("Unexpected reliability without monitors: %+v", empty)
+	}
+}
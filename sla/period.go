@@ -0,0 +1,185 @@
+package sla
+
+import (
+	"sort"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/maintenance"
+)
+
+// Period is the time range from Start (inclusive) to End (exclusive)
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the period, 0 for empty periods
+func (p Period) Duration() time.Duration {
+	if !p.End.After(p.Start) {
+		return 0
+	}
+	return p.End.Sub(p.Start)
+}
+
+// Month returns the calendar month in the given location
+func Month(year int, month time.Month, loc *time.Location) Period {
+	return Period{
+		Start: time.Date(year, month, 1, 0, 0, 0, 0, loc),
+		End:   time.Date(year, month+1, 1, 0, 0, 0, 0, loc),
+	}
+}
+
+// BusinessHours limits the calculation to the same hours on the given days
+type BusinessHours struct {
+	// the days the business hours apply to (Example: Monday to Friday)
+	Days []time.Weekday
+	// the wall clock times the business hours start and end at as offset
+	// from midnight (Example: 9 * time.Hour and 17*time.Hour + 30*time.Minute)
+	Start time.Duration
+	End   time.Duration
+	// the location the wall clock times are evaluated in (nil means UTC)
+	Location *time.Location
+}
+
+// Periods returns the business hours within p
+func (b BusinessHours) Periods(p Period) []Period {
+	loc := b.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	periods := []Period{}
+	day := p.Start.In(loc)
+	for d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc); d.Before(p.End); d = d.AddDate(0, 0, 1) {
+		if !containsWeekday(b.Days, d.Weekday()) {
+			continue
+		}
+		periods = append(periods, Period{
+			Start: wallClock(d, b.Start),
+			End:   wallClock(d, b.End),
+		})
+	}
+	return intersect(periods, []Period{p})
+}
+
+// wallClock returns the time of day on the day d, computed from the fields so
+// daylight saving time changes do not shift it
+func wallClock(d time.Time, offset time.Duration) time.Time {
+	hour, minute := int(offset/time.Hour), int(offset%time.Hour/time.Minute)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, d.Location())
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenancePeriods returns the occurrences of the maintenance windows within
+// p, the start times of recurring windows are evaluated in loc
+func MaintenancePeriods(windows []uptimerobot.MaintenanceWindow, p Period, loc *time.Location) []Period {
+	periods := []Period{}
+	for _, w := range windows {
+		t := p.Start
+		for t.Before(p.End) {
+			o, ok := maintenance.Next(w, t, loc)
+			if !ok || !o.Start.Before(p.End) {
+				break
+			}
+			periods = append(periods, Period{Start: o.Start, End: o.End})
+			t = o.End
+		}
+	}
+	return intersect(normalize(periods), []Period{p})
+}
+
+// normalize sorts the periods and merges overlapping ones
+func normalize(periods []Period) []Period {
+	sorted := []Period{}
+	for _, p := range periods {
+		if p.Duration() > 0 {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []Period{}
+	for _, p := range sorted {
+		if n := len(merged); n > 0 && !p.Start.After(merged[n-1].End) {
+			if p.End.After(merged[n-1].End) {
+				merged[n-1].End = p.End
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+// intersect returns the times covered by both lists of periods
+func intersect(a, b []Period) []Period {
+	a, b = normalize(a), normalize(b)
+
+	out := []Period{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		p := Period{Start: latest(a[i].Start, b[j].Start), End: earliest(a[i].End, b[j].End)}
+		if p.Duration() > 0 {
+			out = append(out, p)
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// subtract returns the times of a not covered by b
+func subtract(a, b []Period) []Period {
+	b = normalize(b)
+
+	out := []Period{}
+	for _, p := range normalize(a) {
+		for _, cut := range b {
+			if !cut.End.After(p.Start) || !cut.Start.Before(p.End) {
+				continue
+			}
+			if cut.Start.After(p.Start) {
+				out = append(out, Period{Start: p.Start, End: cut.Start})
+			}
+			p.Start = cut.End
+		}
+		if p.Duration() > 0 {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// total returns the summed up duration of the periods
+func total(periods []Period) time.Duration {
+	var d time.Duration
+	for _, p := range periods {
+		d += p.Duration()
+	}
+	return d
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
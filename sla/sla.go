@@ -0,0 +1,180 @@
+// Package sla computes the availability of monitors over arbitrary periods
+// from their logs, for example to report the uptime of a calendar month
+// during business hours without the maintenance windows:
+//
+//	mons, err := ur.GetMonitors(&uptimerobot.GetMonitorsInput{Logs: true})
+//	period := sla.Month(2024, time.March, loc)
+//	report := sla.Calculate(mons[0].Logs, period, sla.Options{
+//		BusinessHours: &sla.BusinessHours{
+//			Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+//			Start:    9 * time.Hour,
+//			End:      17 * time.Hour,
+//			Location: loc,
+//		},
+//		Exclude: sla.MaintenancePeriods(windows, period, loc),
+//	})
+//	fmt.Printf("%.3f%% uptime, %s down in %d incidents", report.Uptime(), report.Down, report.Incidents)
+//
+// The logs are state changes: the monitor is down from a down log until the
+// next log, up after an up or started log and paused after a paused log. The
+// time before the first log and after Now is not known and not counted, so the
+// logs have to start before the period to report on all of it.
+//
+// Incidents turns the logs into outages and GroupReliability summarizes them
+// with the mean time to recovery and between failures.
+package sla
+
+import (
+	"sort"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Options controls which times of a period are counted
+type Options struct {
+	// BusinessHours limits the calculation to the business hours (nil counts
+	// the whole period)
+	BusinessHours *BusinessHours
+	// Exclude are periods not counted, for example maintenance windows
+	Exclude []Period
+	// PausedAsDowntime counts the times the monitor was paused as downtime
+	// instead of leaving them out
+	PausedAsDowntime bool
+	// Now is the end of the last state of the logs (zero means time.Now)
+	Now time.Time
+}
+
+// Report is the availability of a monitor within a period
+type Report struct {
+	Period Period
+	// the counted times the monitor was up and down
+	Up   time.Duration
+	Down time.Duration
+	// the counted times the monitor was paused (unless PausedAsDowntime is
+	// set) and the times without logs
+	Paused  time.Duration
+	Unknown time.Duration
+	// the times left out by the business hours and excluded periods
+	Excluded time.Duration
+	// the number of times the monitor went down, including an outage which
+	// started before the period and lasted into it
+	Incidents int
+}
+
+// Monitored returns the counted time the state of the monitor is known for
+func (r Report) Monitored() time.Duration {
+	return r.Up + r.Down
+}
+
+// Uptime returns the percentage of the monitored time the monitor was up, 100
+// if it was not monitored in the period
+func (r Report) Uptime() float64 {
+	if r.Monitored() == 0 {
+		return 100
+	}
+	return float64(r.Up) / float64(r.Monitored()) * 100
+}
+
+// state is the state of a monitor in a part of its timeline
+type state int
+
+const (
+	stateUp state = iota
+	stateDown
+	statePaused
+)
+
+// segment is a part of the timeline of a monitor
+type segment struct {
+	Period
+	state state
+}
+
+// Calculate reports the availability within p from the logs of a monitor
+func Calculate(logs []uptimerobot.Log, p Period, opts Options) Report {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	// The counted parts of the period
+	counted := []Period{p}
+	if opts.BusinessHours != nil {
+		counted = opts.BusinessHours.Periods(p)
+	}
+	counted = subtract(counted, opts.Exclude)
+
+	r := Report{
+		Period:   p,
+		Excluded: p.Duration() - total(counted),
+		Unknown:  total(counted),
+	}
+
+	for _, s := range timeline(logs, now) {
+		parts := intersect(counted, []Period{s.Period})
+		d := total(parts)
+		if d == 0 {
+			continue
+		}
+		r.Unknown -= d
+
+		switch {
+		case s.state == stateUp:
+			r.Up += d
+		case s.state == stateDown, opts.PausedAsDowntime:
+			r.Down += d
+			if s.state == stateDown {
+				r.Incidents++
+			}
+		default:
+			r.Paused += d
+		}
+	}
+	return r
+}
+
+// CalculateMonitor reports the availability of the monitor within p, the
+// monitor has to be fetched with GetMonitorsInput.Logs
+func CalculateMonitor(m uptimerobot.Monitor, p Period, opts Options) Report {
+	return Calculate(m.Logs, p, opts)
+}
+
+// timeline converts the logs into consecutive segments of the states of the
+// monitor, the last one lasting until now
+func timeline(logs []uptimerobot.Log, now time.Time) []segment {
+	sorted := append([]uptimerobot.Log{}, logs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return time.Time(sorted[i].DateTime).Before(time.Time(sorted[j].DateTime))
+	})
+
+	segments := []segment{}
+	for i, l := range sorted {
+		var s state
+		switch l.Type {
+		case uptimerobot.LogTypeDown:
+			s = stateDown
+		case uptimerobot.LogTypeUp, uptimerobot.LogTypeStarted:
+			s = stateUp
+		case uptimerobot.LogTypePaused:
+			s = statePaused
+		default:
+			continue
+		}
+
+		end := now
+		if i+1 < len(sorted) {
+			end = earliest(time.Time(sorted[i+1].DateTime), now)
+		}
+		start := time.Time(l.DateTime)
+
+		// Consecutive logs of the same state, like a down log with a new
+		// reason, continue the segment
+		if n := len(segments); n > 0 && segments[n-1].state == s && segments[n-1].End.Equal(start) {
+			segments[n-1].End = end
+			continue
+		}
+		segments = append(segments, segment{Period: Period{Start: start, End: end}, state: s})
+	}
+	return segments
+}
@@ -0,0 +1,172 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func logAt(typ uptimerobot.LogType, s string) uptimerobot.Log {
+	return uptimerobot.Log{Type: typ, DateTime: uptimerobot.UptimeRobotDate(at(s))}
+}
+
+var logs = []uptimerobot.Log{
+	// Newest first like the API returns them
+	logAt(uptimerobot.LogTypeUp, "2024-03-20 12:00"),
+	logAt(uptimerobot.LogTypeDown, "2024-03-20 10:00"),
+	logAt(uptimerobot.LogTypeStarted, "2024-03-15 00:00"),
+	logAt(uptimerobot.LogTypePaused, "2024-03-14 00:00"),
+	logAt(uptimerobot.LogTypeUp, "2024-03-05 01:00"),
+	logAt(uptimerobot.LogTypeDown, "2024-03-05 00:30"),
+	// A new reason while down continues the outage
+	logAt(uptimerobot.LogTypeDown, "2024-03-05 00:00"),
+	logAt(uptimerobot.LogTypeUp, "2024-02-28 00:00"),
+	logAt(uptimerobot.LogTypeDown, "2024-02-27 00:00"),
+	logAt(uptimerobot.LogTypeStarted, "2024-02-01 00:00"),
+}
+
+func TestCalculate(t *testing.T) {
+	march := Month(2024, time.March, time.UTC)
+	now := at("2024-04-10 00:00")
+
+	for _, tc := range []struct {
+		name      string
+		period    Period
+		opts      Options
+		expected  Report
+		uptimePct float64
+	}{
+		{
+			name:   "month",
+			period: march,
+			opts:   Options{Now: now},
+			expected: Report{
+				Up:        31*24*time.Hour - 24*time.Hour - 3*time.Hour,
+				Down:      3 * time.Hour,
+				Paused:    24 * time.Hour,
+				Incidents: 2,
+			},
+		},
+		{
+			name:   "paused as downtime",
+			period: march,
+			opts:   Options{Now: now, PausedAsDowntime: true},
+			expected: Report{
+				Up:        31*24*time.Hour - 24*time.Hour - 3*time.Hour,
+				Down:      27 * time.Hour,
+				Incidents: 2,
+			},
+		},
+		{
+			name:   "outage lasting into the period",
+			period: Period{Start: at("2024-02-27 12:00"), End: at("2024-02-28 12:00")},
+			opts:   Options{Now: now},
+			expected: Report{
+				Up:        12 * time.Hour,
+				Down:      12 * time.Hour,
+				Incidents: 1,
+			},
+		},
+		{
+			name:   "unknown before the first log and after now",
+			period: Period{Start: at("2024-01-31 00:00"), End: at("2024-02-02 00:00")},
+			opts:   Options{Now: at("2024-02-01 12:00")},
+			expected: Report{
+				Up:      12 * time.Hour,
+				Unknown: 36 * time.Hour,
+			},
+		},
+		{
+			name:   "business hours",
+			period: Period{Start: at("2024-03-18 00:00"), End: at("2024-03-25 00:00")},
+			opts: Options{Now: now, BusinessHours: &BusinessHours{
+				Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+				Start: 9 * time.Hour,
+				End:   17*time.Hour + 30*time.Minute,
+			}},
+			expected: Report{
+				Up:        5*(8*time.Hour+30*time.Minute) - 2*time.Hour,
+				Down:      2 * time.Hour,
+				Excluded:  7*24*time.Hour - 5*(8*time.Hour+30*time.Minute),
+				Incidents: 1,
+			},
+		},
+		{
+			name:   "excluded maintenance",
+			period: Period{Start: at("2024-03-20 00:00"), End: at("2024-03-21 00:00")},
+			opts:   Options{Now: now, Exclude: []Period{{Start: at("2024-03-20 09:00"), End: at("2024-03-20 13:00")}}},
+			expected: Report{
+				Up:       20 * time.Hour,
+				Excluded: 4 * time.Hour,
+			},
+		},
+	} {
+		r := Calculate(logs, tc.period, tc.opts)
+		tc.expected.Period = tc.period
+		if r != tc.expected {
+			t.Errorf("%s: Expected %+v, got %+v", tc.name, tc.expected, r)
+		}
+	}
+}
+
+func TestUptime(t *testing.T) {
+	r := Calculate(logs, Month(2024, time.March, time.UTC), Options{Now: at("2024-04-10 00:00")})
+	if pct := r.Uptime(); pct < 99.58 || pct > 99.59 {
+		t.Errorf("Expected 99.58%% uptime, got %f", pct)
+	}
+
+	if pct := (Report{}).Uptime(); pct != 100 {
+		t.Errorf("Expected 100%% uptime without monitored time, got %f", pct)
+	}
+}
+
+func TestBusinessHoursDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("No time zone data: %s", err)
+	}
+
+	b := BusinessHours{Days: []time.Weekday{time.Sunday}, Start: 9 * time.Hour, End: 17 * time.Hour, Location: berlin}
+	periods := b.Periods(Month(2024, time.March, berlin))
+
+	// 2024-03-31 is the first day of summer time
+	if len(periods) != 5 {
+		t.Fatalf("Expected 5 Sundays, got %+v", periods)
+	}
+	if s := periods[4].Start.In(berlin); s.Hour() != 9 || s.Day() != 31 || periods[4].Duration() != 8*time.Hour {
+		t.Errorf("Unexpected business hours: %+v", periods[4])
+	}
+}
+
+func TestMaintenancePeriods(t *testing.T) {
+	windows := []uptimerobot.MaintenanceWindow{
+		{Type: uptimerobot.MaintenanceWindowTypeDaily, Start: at("0000-01-01 23:00"), Duration: 2 * time.Hour, Status: uptimerobot.MaintenanceWindowStatusActive},
+		{Type: uptimerobot.MaintenanceWindowTypeOnce, Start: at("2024-03-02 12:00"), Duration: time.Hour, Status: uptimerobot.MaintenanceWindowStatusActive},
+	}
+
+	periods := MaintenancePeriods(windows, Period{Start: at("2024-03-01 00:00"), End: at("2024-03-03 00:00")}, time.UTC)
+
+	expected := []Period{
+		{Start: at("2024-03-01 00:00"), End: at("2024-03-01 01:00")},
+		{Start: at("2024-03-01 23:00"), End: at("2024-03-02 01:00")},
+		{Start: at("2024-03-02 12:00"), End: at("2024-03-02 13:00")},
+		{Start: at("2024-03-02 23:00"), End: at("2024-03-03 00:00")},
+	}
+	if len(periods) != len(expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, periods)
+	}
+	for i := range expected {
+		if !periods[i].Start.Equal(expected[i].Start) || !periods[i].End.Equal(expected[i].End) {
+			t.Errorf("Expected %+v, got %+v", expected[i], periods[i])
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package uptimerobot
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type UptimeRobotDate time.Time
 
@@ -9,6 +12,12 @@ func (t UptimeRobotDate) MarshalJSON() ([]byte, error) {
 }
 
 func (t *UptimeRobotDate) UnmarshalJSON(in []byte) error {
+	// The v2 API delivers Unix timestamps
+	if sec, err := strconv.ParseInt(string(in), 10, 64); err == nil {
+		*t = UptimeRobotDate(time.Unix(sec, 0).UTC())
+		return nil
+	}
+
 	p, err := time.Parse("\"01/02/2006 15:04:05\"", string(in))
 	if err != nil {
 		// Some API parts are delivering a different date format so we handle this too
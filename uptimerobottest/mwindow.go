@@ -0,0 +1,221 @@
+package uptimerobottest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// AddMaintenanceWindow stores a maintenance window without going through the
+// API and returns it with the assigned ID. The window is added to the
+// maintenance windows of its Monitors.
+func (s *Server) AddMaintenanceWindow(w uptimerobot.MaintenanceWindow) uptimerobot.MaintenanceWindow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.ID = s.newID()
+	for _, id := range w.Monitors {
+		if m, ok := s.monitors[id]; ok {
+			m.MaintenanceWindows = append(m.MaintenanceWindows, w.ID)
+		}
+	}
+	w.Monitors = nil
+	s.mwindows[w.ID] = &w
+	return s.withMonitors(w)
+}
+
+// MaintenanceWindows returns a copy of all stored maintenance windows ordered
+// by ID
+func (s *Server) MaintenanceWindows() []uptimerobot.MaintenanceWindow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []uptimerobot.MaintenanceWindow{}
+	for _, id := range s.mwindowIDs() {
+		out = append(out, s.withMonitors(*s.mwindows[id]))
+	}
+	return out
+}
+
+// withMonitors returns the window with the IDs of the monitors referencing it
+func (s *Server) withMonitors(w uptimerobot.MaintenanceWindow) uptimerobot.MaintenanceWindow {
+	w.Monitors = []int{}
+	for _, id := range s.monitorIDs() {
+		for _, wid := range s.monitors[id].MaintenanceWindows {
+			if wid == w.ID {
+				w.Monitors = append(w.Monitors, id)
+			}
+		}
+	}
+	return w
+}
+
+func (s *Server) getMWindows(params url.Values) (interface{}, uptimerobot.APIError) {
+	ids, ok := parseIntSet(params.Get("mwindows"))
+	if !ok {
+		return nil, uptimerobot.ErrorMWindowValueInvalid
+	}
+
+	matches := []map[string]interface{}{}
+	for _, id := range s.mwindowIDs() {
+		if ids == nil || ids[id] {
+			matches = append(matches, mwindowJSON(s.mwindows[id]))
+		}
+	}
+
+	offset, limit := s.page(params, len(matches))
+	return map[string]interface{}{
+		"stat":       "ok",
+		"pagination": pagination(offset, limit, len(matches)),
+		"mwindows":   matches[offset:pageEnd(offset, limit, len(matches))],
+	}, 0
+}
+
+func (s *Server) newMWindow(params url.Values) (interface{}, uptimerobot.APIError) {
+	w := &uptimerobot.MaintenanceWindow{Status: uptimerobot.MaintenanceWindowStatusActive}
+	if code := s.applyMWindowParams(w, params); code != 0 {
+		return nil, code
+	}
+
+	w.ID = s.newID()
+	s.mwindows[w.ID] = w
+	return map[string]interface{}{
+		"stat":    "ok",
+		"mwindow": map[string]int{"id": w.ID, "status": int(w.Status)},
+	}, 0
+}
+
+func (s *Server) editMWindow(params url.Values) (interface{}, uptimerobot.APIError) {
+	w, code := s.lookupMWindow(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	edited := *w
+	if code := s.applyMWindowParams(&edited, params); code != 0 {
+		return nil, code
+	}
+
+	switch params.Get("status") {
+	case "":
+	case "0":
+		edited.Status = uptimerobot.MaintenanceWindowStatusPaused
+	case "1":
+		edited.Status = uptimerobot.MaintenanceWindowStatusActive
+	default:
+		return nil, uptimerobot.ErrorMWindowValueInvalid
+	}
+	*w = edited
+
+	return map[string]interface{}{
+		"stat":    "ok",
+		"mwindow": map[string]int{"id": w.ID},
+	}, 0
+}
+
+func (s *Server) deleteMWindow(params url.Values) (interface{}, uptimerobot.APIError) {
+	w, code := s.lookupMWindow(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	delete(s.mwindows, w.ID)
+	for _, m := range s.monitors {
+		m.MaintenanceWindows = withoutID(m.MaintenanceWindows, w.ID)
+	}
+	return map[string]interface{}{
+		"stat":    "ok",
+		"mwindow": map[string]int{"id": w.ID},
+	}, 0
+}
+
+// applyMWindowParams copies the parameters of a newMWindow or editMWindow call
+// into w, validating them like the real API does
+func (s *Server) applyMWindowParams(w *uptimerobot.MaintenanceWindow, params url.Values) uptimerobot.APIError {
+	t, err := strconv.Atoi(params.Get("type"))
+	if err != nil || t < int(uptimerobot.MaintenanceWindowTypeOnce) || t > int(uptimerobot.MaintenanceWindowTypeMonthly) {
+		return uptimerobot.ErrorMWindowValueInvalid
+	}
+	w.Type = uptimerobot.MaintenanceWindowType(t)
+
+	w.FriendlyName = params.Get("friendly_name")
+	if w.FriendlyName == "" {
+		return uptimerobot.ErrorMWindowValueInvalid
+	}
+
+	maxDay := 0
+	switch w.Type {
+	case uptimerobot.MaintenanceWindowTypeWeekly:
+		maxDay = 7
+	case uptimerobot.MaintenanceWindowTypeMonthly:
+		maxDay = 31
+	}
+
+	// One-time windows start at a Unix timestamp, the others at a time of day
+	if w.Type == uptimerobot.MaintenanceWindowTypeOnce {
+		sec, err := strconv.ParseInt(params.Get("start_time"), 10, 64)
+		if err != nil {
+			return uptimerobot.ErrorMWindowValueInvalid
+		}
+		w.Start = time.Unix(sec, 0).UTC()
+	} else if w.Start, err = time.Parse("15:04", params.Get("start_time")); err != nil {
+		return uptimerobot.ErrorMWindowValueInvalid
+	}
+
+	d, err := strconv.Atoi(params.Get("duration"))
+	if err != nil || d <= 0 {
+		return uptimerobot.ErrorMWindowValueInvalid
+	}
+	w.Duration = time.Duration(d) * time.Minute
+
+	w.Days = nil
+	if maxDay > 0 {
+		days, ok := parseIntSet(params.Get("value"))
+		if !ok || len(days) == 0 {
+			return uptimerobot.ErrorMWindowValueInvalid
+		}
+		for day := range days {
+			if day < 1 || day > maxDay {
+				return uptimerobot.ErrorMWindowValueInvalid
+			}
+			w.Days = append(w.Days, day)
+		}
+		sort.Ints(w.Days)
+	}
+
+	return 0
+}
+
+func (s *Server) lookupMWindow(params url.Values) (*uptimerobot.MaintenanceWindow, uptimerobot.APIError) {
+	id, err := strconv.Atoi(params.Get("id"))
+	if err != nil {
+		return nil, uptimerobot.ErrorMWindowIDNotExists
+	}
+
+	w, ok := s.mwindows[id]
+	if !ok {
+		return nil, uptimerobot.ErrorMWindowIDNotExists
+	}
+	return w, 0
+}
+
+func (s *Server) mwindowIDs() []int {
+	ids := make([]int, 0, len(s.mwindows))
+	for id := range s.mwindows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func joinInts(in []int) string {
+	parts := make([]string, 0, len(in))
+	for _, i := range in {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,192 @@
+package uptimerobottest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// AddPublicStatusPage stores a status page without going through the API and
+// returns it with the assigned ID
+func (s *Server) AddPublicStatusPage(p uptimerobot.PublicStatusPage) uptimerobot.PublicStatusPage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.ID = s.newID()
+	p.StandardURL = "https://stats.uptimerobot.com/" + strconv.Itoa(p.ID)
+	s.psps[p.ID] = &p
+	return p
+}
+
+// PublicStatusPages returns a copy of all stored status pages ordered by ID
+func (s *Server) PublicStatusPages() []uptimerobot.PublicStatusPage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []uptimerobot.PublicStatusPage{}
+	for _, id := range s.pspIDs() {
+		out = append(out, *s.psps[id])
+	}
+	return out
+}
+
+func (s *Server) getPSPs(params url.Values) (interface{}, uptimerobot.APIError) {
+	ids, ok := parseIntSet(params.Get("psps"))
+	if !ok {
+		return nil, uptimerobot.ErrorPSPValueInvalid
+	}
+
+	matches := []map[string]interface{}{}
+	for _, id := range s.pspIDs() {
+		if ids != nil && !ids[id] {
+			continue
+		}
+
+		// 0 stands for all monitors of the account
+		p := s.psps[id]
+		var monitors interface{} = p.Monitors
+		if len(p.Monitors) == 0 {
+			monitors = 0
+		}
+		matches = append(matches, map[string]interface{}{
+			"id":            p.ID,
+			"friendly_name": p.FriendlyName,
+			"monitors":      monitors,
+			"custom_url":    p.CustomDomain,
+			"sort":          int(p.Sort),
+			"status":        int(p.Status),
+			"standard_url":  p.StandardURL,
+		})
+	}
+
+	offset, limit := s.page(params, len(matches))
+	return map[string]interface{}{
+		"stat":       "ok",
+		"pagination": pagination(offset, limit, len(matches)),
+		"psps":       matches[offset:pageEnd(offset, limit, len(matches))],
+	}, 0
+}
+
+func (s *Server) newPSP(params url.Values) (interface{}, uptimerobot.APIError) {
+	p := &uptimerobot.PublicStatusPage{
+		Sort:   uptimerobot.PublicStatusPageSortFriendlyNameAZ,
+		Status: uptimerobot.PublicStatusPageStatusActive,
+	}
+	if code := s.applyPSPParams(p, params); code != 0 {
+		return nil, code
+	}
+
+	p.ID = s.newID()
+	p.StandardURL = "https://stats.uptimerobot.com/" + strconv.Itoa(p.ID)
+	s.psps[p.ID] = p
+	return map[string]interface{}{
+		"stat": "ok",
+		"psp":  map[string]int{"id": p.ID, "status": int(p.Status)},
+	}, 0
+}
+
+func (s *Server) editPSP(params url.Values) (interface{}, uptimerobot.APIError) {
+	p, code := s.lookupPSP(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	edited := *p
+	if code := s.applyPSPParams(&edited, params); code != 0 {
+		return nil, code
+	}
+
+	switch params.Get("status") {
+	case "":
+	case "0":
+		edited.Status = uptimerobot.PublicStatusPageStatusPaused
+	case "1":
+		edited.Status = uptimerobot.PublicStatusPageStatusActive
+	default:
+		return nil, uptimerobot.ErrorPSPValueInvalid
+	}
+	*p = edited
+
+	return map[string]interface{}{
+		"stat": "ok",
+		"psp":  map[string]int{"id": p.ID},
+	}, 0
+}
+
+func (s *Server) deletePSP(params url.Values) (interface{}, uptimerobot.APIError) {
+	p, code := s.lookupPSP(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	delete(s.psps, p.ID)
+	return map[string]interface{}{
+		"stat": "ok",
+		"psp":  map[string]int{"id": p.ID},
+	}, 0
+}
+
+// applyPSPParams copies the parameters of a newPSP or editPSP call into p,
+// validating them like the real API does
+func (s *Server) applyPSPParams(p *uptimerobot.PublicStatusPage, params url.Values) uptimerobot.APIError {
+	p.FriendlyName = params.Get("friendly_name")
+	if p.FriendlyName == "" {
+		return uptimerobot.ErrorPSPValueInvalid
+	}
+
+	p.Monitors = nil
+	if v := params.Get("monitors"); v != "0" {
+		ids, ok := parseIntSet(v)
+		if !ok || len(ids) == 0 {
+			return uptimerobot.ErrorPSPValueInvalid
+		}
+		for id := range ids {
+			if _, ok := s.monitors[id]; !ok {
+				return uptimerobot.ErrorPSPValueInvalid
+			}
+			p.Monitors = append(p.Monitors, id)
+		}
+		sort.Ints(p.Monitors)
+	}
+
+	if v, ok := params["custom_domain"]; ok {
+		p.CustomDomain = v[0]
+	}
+	if v := params.Get("password"); v != "" {
+		p.Password = v
+	}
+
+	if v := params.Get("sort"); v != "" {
+		order, err := strconv.Atoi(v)
+		if err != nil || order < int(uptimerobot.PublicStatusPageSortFriendlyNameAZ) || order > int(uptimerobot.PublicStatusPageSortStatusDownUp) {
+			return uptimerobot.ErrorPSPValueInvalid
+		}
+		p.Sort = uptimerobot.PublicStatusPageSort(order)
+	}
+
+	return 0
+}
+
+func (s *Server) lookupPSP(params url.Values) (*uptimerobot.PublicStatusPage, uptimerobot.APIError) {
+	id, err := strconv.Atoi(params.Get("id"))
+	if err != nil {
+		return nil, uptimerobot.ErrorPSPIDNotExists
+	}
+
+	p, ok := s.psps[id]
+	if !ok {
+		return nil, uptimerobot.ErrorPSPIDNotExists
+	}
+	return p, 0
+}
+
+func (s *Server) pspIDs() []int {
+	ids := make([]int, 0, len(s.psps))
+	for id := range s.psps {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
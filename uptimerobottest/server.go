@@ -0,0 +1,664 @@
+// Package uptimerobottest provides an in-process fake of the UptimeRobot API
+// for hermetic tests of code using the uptimerobot client.
+//
+// The fake speaks the v2 API: it keeps monitors, alert contacts, maintenance
+// windows and public status pages in memory, paginates its results like the
+// real API and answers invalid requests with the errors the client maps to the
+// codes declared in the uptimerobot package:
+//
+//	srv := uptimerobottest.NewServer("u1234-testkey")
+//	defer srv.Close()
+//
+//	ur := srv.Client()
+//	monitors, err := ur.GetMonitors(nil)
+package uptimerobottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// DefaultPageLimit is the maximum number of items returned per page, matching
+// the limit of the real API
+const DefaultPageLimit = 50
+
+// Server is a fake UptimeRobot API listening on a local address
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the only API-key accepted by the server
+	APIKey string
+	// PageLimit is the maximum number of items returned per page
+	PageLimit int
+	// MonitorLimit is reported as the monitor limit of the account
+	MonitorLimit int
+	// MonitorInterval is reported as the minimal monitoring interval (in
+	// minutes) of the account
+	MonitorInterval int
+
+	mu       sync.Mutex
+	nextID   int
+	monitors map[int]*uptimerobot.Monitor
+	contacts map[int]*uptimerobot.AlertContact
+	mwindows map[int]*uptimerobot.MaintenanceWindow
+	psps     map[int]*uptimerobot.PublicStatusPage
+}
+
+// NewServer starts a fake API accepting the given API-key. The caller has to
+// call Close when finished.
+func NewServer(apikey string) *Server {
+	s := &Server{
+		APIKey:          apikey,
+		PageLimit:       DefaultPageLimit,
+		MonitorLimit:    50,
+		MonitorInterval: 5,
+		nextID:          777000000,
+		monitors:        map[int]*uptimerobot.Monitor{},
+		contacts:        map[int]*uptimerobot.AlertContact{},
+		mwindows:        map[int]*uptimerobot.MaintenanceWindow{},
+		psps:            map[int]*uptimerobot.PublicStatusPage{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Client returns a client for the fake API using the API-key of the server
+func (s *Server) Client() *uptimerobot.UptimeRobot {
+	ur := uptimerobot.New(s.APIKey)
+	ur.BaseURL = s.URL
+	ur.HTTPClient = s.Server.Client()
+	return ur
+}
+
+// AddMonitor stores a monitor without going through the API and returns it
+// with the assigned ID. Use it to prepare the state for a test.
+func (s *Server) AddMonitor(m uptimerobot.Monitor) uptimerobot.Monitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m.ID = s.newID()
+	s.monitors[m.ID] = &m
+	return m
+}
+
+// AddAlertContact stores an alert contact without going through the API and
+// returns it with the assigned ID
+func (s *Server) AddAlertContact(c uptimerobot.AlertContact) uptimerobot.AlertContact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.ID = s.newID()
+	s.contacts[c.ID] = &c
+	return c
+}
+
+// Monitors returns a copy of all stored monitors ordered by ID
+func (s *Server) Monitors() []uptimerobot.Monitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []uptimerobot.Monitor{}
+	for _, id := range s.monitorIDs() {
+		out = append(out, *s.monitors[id])
+	}
+	return out
+}
+
+// AlertContacts returns a copy of all stored alert contacts ordered by ID
+func (s *Server) AlertContacts() []uptimerobot.AlertContact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []uptimerobot.AlertContact{}
+	for _, id := range s.contactIDs() {
+		out = append(out, *s.contacts[id])
+	}
+	return out
+}
+
+type handlerFunc func(params url.Values) (interface{}, uptimerobot.APIError)
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// The API reads the parameters from the form body of POST requests
+	r.ParseForm()
+	params := r.Form
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	handlers := map[string]handlerFunc{
+		"getAccountDetails":  s.getAccountDetails,
+		"getMonitors":        s.getMonitors,
+		"newMonitor":         s.newMonitor,
+		"editMonitor":        s.editMonitor,
+		"deleteMonitor":      s.deleteMonitor,
+		"resetMonitor":       s.resetMonitor,
+		"getAlertContacts":   s.getAlertContacts,
+		"newAlertContact":    s.newAlertContact,
+		"editAlertContact":   s.editAlertContact,
+		"deleteAlertContact": s.deleteAlertContact,
+		"getMWindows":        s.getMWindows,
+		"newMWindow":         s.newMWindow,
+		"editMWindow":        s.editMWindow,
+		"deleteMWindow":      s.deleteMWindow,
+		"getPSPs":            s.getPSPs,
+		"newPSP":             s.newPSP,
+		"editPSP":            s.editPSP,
+		"deletePSP":          s.deletePSP,
+	}
+
+	s.mu.Lock()
+	var (
+		res  interface{}
+		code uptimerobot.APIError
+	)
+	switch h, ok := handlers[method]; {
+	case params.Get("api_key") == "":
+		code = uptimerobot.ErrorAPIKeyWrongFormat
+	case params.Get("api_key") != s.APIKey:
+		code = uptimerobot.ErrorAPIKeyWrong
+	case params.Get("format") != "json":
+		code = uptimerobot.ErrorWrongFormat
+	case !ok:
+		code = uptimerobot.ErrorNoSuchMethod
+	default:
+		res, code = h(params)
+	}
+	s.mu.Unlock()
+
+	if code != 0 {
+		res = errorResponse(code)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) getAccountDetails(params url.Values) (interface{}, uptimerobot.APIError) {
+	counts := map[uptimerobot.MonitorStatus]int{}
+	for _, m := range s.monitors {
+		counts[m.Status]++
+	}
+
+	return map[string]interface{}{
+		"stat": "ok",
+		"account": map[string]interface{}{
+			"email":            "test@example.com",
+			"monitor_limit":    s.MonitorLimit,
+			"monitor_interval": s.MonitorInterval,
+			"up_monitors":      counts[uptimerobot.MonitorStatusUp],
+			"down_monitors":    counts[uptimerobot.MonitorStatusSeemsDown] + counts[uptimerobot.MonitorStatusDown],
+			"paused_monitors":  counts[uptimerobot.MonitorStatusPaused],
+		},
+	}, 0
+}
+
+func (s *Server) getMonitors(params url.Values) (interface{}, uptimerobot.APIError) {
+	ids, ok := parseIntSet(params.Get("monitors"))
+	if !ok {
+		return nil, uptimerobot.ErrorMonitorIDShouldBeInteger
+	}
+	types, _ := parseIntSet(params.Get("types"))
+	statuses, _ := parseIntSet(params.Get("statuses"))
+	search := strings.ToLower(params.Get("search"))
+
+	matches := []map[string]interface{}{}
+	for _, id := range s.monitorIDs() {
+		m := s.monitors[id]
+		if (ids != nil && !ids[m.ID]) ||
+			(types != nil && !types[int(m.Type)]) ||
+			(statuses != nil && !statuses[int(m.Status)]) ||
+			(search != "" && !strings.Contains(strings.ToLower(m.URL), search) && !strings.Contains(strings.ToLower(m.FriendlyName), search)) {
+			continue
+		}
+		matches = append(matches, s.monitorJSON(m, params))
+	}
+
+	offset, limit := s.page(params, len(matches))
+	return map[string]interface{}{
+		"stat":       "ok",
+		"pagination": pagination(offset, limit, len(matches)),
+		"monitors":   matches[offset:pageEnd(offset, limit, len(matches))],
+	}, 0
+}
+
+func (s *Server) newMonitor(params url.Values) (interface{}, uptimerobot.APIError) {
+	m := &uptimerobot.Monitor{
+		Status:   uptimerobot.MonitorStatusNotCheckedYet,
+		Interval: 5 * 60,
+	}
+	if code := s.applyMonitorParams(m, params, true); code != 0 {
+		return nil, code
+	}
+
+	for _, o := range s.monitors {
+		if o.URL == m.URL && o.Type == m.Type && o.Port == m.Port {
+			return nil, uptimerobot.ErrorMonitorAlreadyExists
+		}
+	}
+
+	m.ID = s.newID()
+	s.monitors[m.ID] = m
+	return map[string]interface{}{
+		"stat":    "ok",
+		"monitor": map[string]int{"id": m.ID, "status": int(m.Status)},
+	}, 0
+}
+
+func (s *Server) editMonitor(params url.Values) (interface{}, uptimerobot.APIError) {
+	m, code := s.lookupMonitor(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	// the API cannot change the type of a monitor
+	if v := params.Get("type"); v != "" && v != strconv.Itoa(int(m.Type)) {
+		return nil, uptimerobot.ErrorMonitorTypeInvalid
+	}
+
+	edited := *m
+	if code := s.applyMonitorParams(&edited, params, false); code != 0 {
+		return nil, code
+	}
+
+	// status 0 pauses and 1 resumes the monitor
+	switch params.Get("status") {
+	case "":
+	case "0":
+		edited.Status = uptimerobot.MonitorStatusPaused
+	case "1":
+		if edited.Status == uptimerobot.MonitorStatusPaused {
+			edited.Status = uptimerobot.MonitorStatusNotCheckedYet
+		}
+	default:
+		return nil, uptimerobot.ErrorNoEditsFound
+	}
+	*m = edited
+
+	return map[string]interface{}{
+		"stat":    "ok",
+		"monitor": map[string]int{"id": m.ID},
+	}, 0
+}
+
+func (s *Server) deleteMonitor(params url.Values) (interface{}, uptimerobot.APIError) {
+	m, code := s.lookupMonitor(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	delete(s.monitors, m.ID)
+	for _, p := range s.psps {
+		p.Monitors = withoutID(p.Monitors, m.ID)
+	}
+	return map[string]interface{}{
+		"stat":    "ok",
+		"monitor": map[string]int{"id": m.ID},
+	}, 0
+}
+
+func (s *Server) resetMonitor(params url.Values) (interface{}, uptimerobot.APIError) {
+	m, code := s.lookupMonitor(params)
+	if code != 0 {
+		return nil, code
+	}
+
+	m.Logs = nil
+	m.ResponseTimes = nil
+	m.AlltimeUptimeRatio = 0
+	m.CustomUptimeRatio = 0
+	m.CustomUptimeRatios = nil
+	return map[string]interface{}{
+		"stat":    "ok",
+		"monitor": map[string]int{"id": m.ID},
+	}, 0
+}
+
+func (s *Server) getAlertContacts(params url.Values) (interface{}, uptimerobot.APIError) {
+	ids, ok := parseIntSet(params.Get("alert_contacts"))
+	if !ok {
+		return nil, uptimerobot.ErrorAlertContactIDShoudBeInteger
+	}
+
+	matches := []map[string]interface{}{}
+	for _, id := range s.contactIDs() {
+		if ids == nil || ids[id] {
+			matches = append(matches, alertContactJSON(*s.contacts[id]))
+		}
+	}
+
+	// Unlike the other list methods this one reports the paging information
+	// at the top level
+	offset, limit := s.page(params, len(matches))
+	return map[string]interface{}{
+		"stat":           "ok",
+		"offset":         offset,
+		"limit":          limit,
+		"total":          len(matches),
+		"alert_contacts": matches[offset:pageEnd(offset, limit, len(matches))],
+	}, 0
+}
+
+func (s *Server) newAlertContact(params url.Values) (interface{}, uptimerobot.APIError) {
+	if params.Get("type") == "" || params.Get("value") == "" {
+		return nil, uptimerobot.ErrorAlertContactTypeAndValueRequired
+	}
+
+	t, err := strconv.Atoi(params.Get("type"))
+	if err != nil || t < int(uptimerobot.AlertContactTypeSMS) || t > int(uptimerobot.AlertContactTypeSlack) {
+		return nil, uptimerobot.ErrorAlertContactTypeNotSupported
+	}
+
+	c := &uptimerobot.AlertContact{
+		Type:         uptimerobot.AlertContactType(t),
+		Value:        params.Get("value"),
+		FriendlyName: params.Get("friendly_name"),
+		Status:       uptimerobot.AlertContactStatusNotActivated,
+	}
+
+	if c.Type == uptimerobot.AlertContactTypeEMail && !strings.Contains(c.Value, "@") {
+		return nil, uptimerobot.ErrorAlertContactValueShouldBeEMail
+	}
+
+	for _, o := range s.contacts {
+		if o.Type == c.Type && o.Value == c.Value {
+			return nil, uptimerobot.ErrorAlertContactAlreadyExists
+		}
+	}
+
+	c.ID = s.newID()
+	s.contacts[c.ID] = c
+	return map[string]interface{}{
+		"stat":         "ok",
+		"alertcontact": map[string]int{"id": c.ID, "status": int(c.Status)},
+	}, 0
+}
+
+func (s *Server) editAlertContact(params url.Values) (interface{}, uptimerobot.APIError) {
+	id, err := strconv.Atoi(params.Get("id"))
+	if err != nil {
+		return nil, uptimerobot.ErrorAlertContactIDShoudBeInteger
+	}
+
+	c, ok := s.contacts[id]
+	if !ok {
+		return nil, uptimerobot.ErrorAlertContactIDNotExists
+	}
+
+	edited := *c
+	if v := params.Get("value"); v != "" {
+		edited.Value = v
+	}
+	if v := params.Get("friendly_name"); v != "" {
+		edited.FriendlyName = v
+	}
+	if v := params.Get("status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil || (status != int(uptimerobot.AlertContactStatusPaused) && status != int(uptimerobot.AlertContactStatusActive)) {
+			return nil, uptimerobot.ErrorNoEditsFound
+		}
+		edited.Status = uptimerobot.AlertContactStatus(status)
+	}
+
+	if edited == *c {
+		return nil, uptimerobot.ErrorNoEditsFound
+	}
+
+	if edited.Type == uptimerobot.AlertContactTypeEMail && !strings.Contains(edited.Value, "@") {
+		return nil, uptimerobot.ErrorAlertContactValueShouldBeEMail
+	}
+
+	for _, o := range s.contacts {
+		if o.ID != id && o.Type == edited.Type && o.Value == edited.Value {
+			return nil, uptimerobot.ErrorAlertContactAlreadyExists
+		}
+	}
+
+	*c = edited
+	return map[string]interface{}{
+		"stat":          "ok",
+		"alert_contact": map[string]int{"id": id},
+	}, 0
+}
+
+func (s *Server) deleteAlertContact(params url.Values) (interface{}, uptimerobot.APIError) {
+	id, err := strconv.Atoi(params.Get("id"))
+	if err != nil {
+		return nil, uptimerobot.ErrorAlertContactIDShoudBeInteger
+	}
+
+	if _, ok := s.contacts[id]; !ok {
+		return nil, uptimerobot.ErrorAlertContactIDNotExists
+	}
+
+	delete(s.contacts, id)
+	for _, m := range s.monitors {
+		kept := []uptimerobot.AlertContact{}
+		for _, c := range m.AlertContacts {
+			if c.ID != id {
+				kept = append(kept, c)
+			}
+		}
+		m.AlertContacts = kept
+	}
+
+	return map[string]interface{}{
+		"stat":          "ok",
+		"alert_contact": map[string]int{"id": id},
+	}, 0
+}
+
+// applyMonitorParams copies the monitor parameters of a newMonitor or
+// editMonitor call into m, validating them like the real API does
+func (s *Server) applyMonitorParams(m *uptimerobot.Monitor, params url.Values, create bool) uptimerobot.APIError {
+	if v := params.Get("friendly_name"); v != "" {
+		m.FriendlyName = v
+	} else if create {
+		return uptimerobot.ErrorMonitorFriendlyNameRequired
+	}
+
+	if v := params.Get("url"); v != "" {
+		m.URL = v
+	} else if create {
+		return uptimerobot.ErrorMonitorURLInvalid
+	}
+
+	if v := params.Get("type"); v != "" || create {
+		t, err := strconv.Atoi(v)
+		if err != nil || t < int(uptimerobot.MonitorTypeHTTP) || t > int(uptimerobot.MonitorTypePort) {
+			return uptimerobot.ErrorMonitorTypeInvalid
+		}
+		m.Type = uptimerobot.MonitorType(t)
+	}
+
+	intParams := []struct {
+		name string
+		code uptimerobot.APIError
+		set  func(int)
+	}{
+		{"sub_type", uptimerobot.ErrorMonitorSubTypeInvalid, func(i int) { m.Subtype = uptimerobot.MonitorSubtype(i) }},
+		{"keyword_type", uptimerobot.ErrorMonitorKeywordTypeInvalid, func(i int) { m.KeywordType = uptimerobot.MonitorKeywordType(i) }},
+		{"port", uptimerobot.ErrorMonitorPortInvalid, func(i int) { m.Port = i }},
+	}
+	for _, p := range intParams {
+		if v := params.Get(p.name); v != "" {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return p.code
+			}
+			p.set(i)
+		}
+	}
+
+	if i, err := strconv.Atoi(params.Get("interval")); err == nil {
+		m.Interval = i
+	}
+
+	if v, ok := params["keyword_value"]; ok {
+		m.KeywordValue = v[0]
+	}
+	if v, ok := params["http_username"]; ok {
+		m.HTTPUsername = v[0]
+	}
+	if v, ok := params["http_password"]; ok {
+		m.HTTPPassword = v[0]
+	}
+
+	switch {
+	case m.Type == uptimerobot.MonitorTypePort && m.Subtype == 0:
+		return uptimerobot.ErrorMonitorSubTypeRequired
+	case m.Type == uptimerobot.MonitorTypeKeyword && (m.KeywordType == 0 || m.KeywordValue == ""):
+		return uptimerobot.ErrorMonitorKeywordTypeAndKeywordValueRequired
+	case (m.HTTPUsername == "") != (m.HTTPPassword == ""):
+		return uptimerobot.ErrorHTTPCredentialsMismatch
+	}
+
+	if v := params.Get("alert_contacts"); v != "" {
+		contacts := []uptimerobot.AlertContact{}
+		for _, spec := range strings.Split(v, "-") {
+			parts := strings.Split(spec, "_")
+			nums := make([]int, 3)
+			for i := range parts {
+				n, err := strconv.Atoi(parts[i])
+				if err != nil || i >= len(nums) {
+					return uptimerobot.ErrorMonitorAlertContactsValueInvalid
+				}
+				nums[i] = n
+			}
+
+			c, ok := s.contacts[nums[0]]
+			if !ok {
+				return uptimerobot.ErrorMonitorAlertContactsValueInvalid
+			}
+			ac := *c
+			ac.Threshold = nums[1]
+			ac.Recurrence = nums[2]
+			contacts = append(contacts, ac)
+		}
+		m.AlertContacts = contacts
+	}
+
+	if v, ok := params["mwindows"]; ok {
+		ids, ok := parseIntSet(v[0])
+		if !ok {
+			return uptimerobot.ErrorMWindowIDNotExists
+		}
+		m.MaintenanceWindows = nil
+		for id := range ids {
+			if _, ok := s.mwindows[id]; !ok {
+				return uptimerobot.ErrorMWindowIDNotExists
+			}
+			m.MaintenanceWindows = append(m.MaintenanceWindows, id)
+		}
+		sort.Ints(m.MaintenanceWindows)
+	}
+
+	if v := params.Get("custom_http_headers"); v != "" {
+		headers := map[string]string{}
+		if err := json.Unmarshal([]byte(v), &headers); err != nil {
+			return uptimerobot.ErrorNoEditsFound
+		}
+		m.CustomHTTPHeaders = headers
+	}
+
+	return 0
+}
+
+func (s *Server) lookupMonitor(params url.Values) (*uptimerobot.Monitor, uptimerobot.APIError) {
+	if params.Get("id") == "" {
+		return nil, uptimerobot.ErrorMonitorIDRequired
+	}
+
+	id, err := strconv.Atoi(params.Get("id"))
+	if err != nil {
+		return nil, uptimerobot.ErrorMonitorIDShouldBeInteger
+	}
+
+	m, ok := s.monitors[id]
+	if !ok {
+		return nil, uptimerobot.ErrorMonitorIDNoExists
+	}
+	return m, 0
+}
+
+// page returns the offset and limit of the requested page clamped to the
+// number of available items
+func (s *Server) page(params url.Values, total int) (int, int) {
+	limit, err := strconv.Atoi(params.Get("limit"))
+	if err != nil || limit <= 0 || limit > s.PageLimit {
+		limit = s.PageLimit
+	}
+
+	offset, err := strconv.Atoi(params.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	return offset, limit
+}
+
+func pageEnd(offset, limit, total int) int {
+	if offset+limit > total {
+		return total
+	}
+	return offset + limit
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) monitorIDs() []int {
+	ids := make([]int, 0, len(s.monitors))
+	for id := range s.monitors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (s *Server) contactIDs() []int {
+	ids := make([]int, 0, len(s.contacts))
+	for id := range s.contacts {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func withoutID(ids []int, id int) []int {
+	kept := []int{}
+	for _, i := range ids {
+		if i != id {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+// parseIntSet parses a dash separated list of integers as used by the API
+// ("1-2-3"). It returns nil for an empty list.
+func parseIntSet(in string) (map[int]bool, bool) {
+	if in == "" {
+		return nil, true
+	}
+
+	out := map[int]bool{}
+	for _, part := range strings.Split(in, "-") {
+		i, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		out[i] = true
+	}
+	return out, true
+}
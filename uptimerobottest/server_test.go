@@ -0,0 +1,231 @@
+package uptimerobottest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+func TestMonitorFlow(t *testing.T) {
+	srv := NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	ac, err := ur.NewAlertContact(uptimerobot.AlertContact{
+		Type:  uptimerobot.AlertContactTypeEMail,
+		Value: "ops@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unable to create required contact: %s", err)
+	}
+
+	m, err := ur.NewOrEditMonitor(uptimerobot.Monitor{
+		FriendlyName:  "example",
+		URL:           "http://www.example.com/",
+		Type:          uptimerobot.MonitorTypeHTTP,
+		Interval:      600,
+		AlertContacts: []uptimerobot.AlertContact{*ac},
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if m.ID == 0 {
+		t.Fatalf("Expected to get a monitor ID but got none")
+	}
+
+	m.FriendlyName = "renamed"
+	if _, err := ur.NewOrEditMonitor(*m); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	retyped := *m
+	retyped.Type = uptimerobot.MonitorTypePing
+	if _, err := ur.NewOrEditMonitor(retyped); !errors.Is(err, uptimerobot.ErrorMonitorTypeInvalid) {
+		t.Errorf("Expected ErrorMonitorTypeInvalid when changing the type, got: %v", err)
+	}
+
+	mons, err := ur.GetMonitors(&uptimerobot.GetMonitorsInput{ShowMonitorAlertContacts: true})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(mons) != 1 || mons[0].ID != m.ID || mons[0].FriendlyName != "renamed" || mons[0].Interval != 600 {
+		t.Fatalf("Unexpected monitors: %+v", mons)
+	}
+
+	if len(mons[0].AlertContacts) != 1 || mons[0].AlertContacts[0].ID != ac.ID {
+		t.Errorf("Did not have expected alert contact assigned to monitor: %+v", mons[0].AlertContacts)
+	}
+
+	if err := ur.DeleteMonitor(m.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if err := ur.DeleteMonitor(m.ID); !errors.Is(err, uptimerobot.ErrorMonitorIDNoExists) {
+		t.Errorf("Expected ErrorMonitorIDNoExists, got: %v", err)
+	}
+
+	mons, err = ur.GetMonitors(nil)
+	if err != nil || len(mons) != 0 {
+		t.Errorf("Expected no monitors after delete, got %+v (%v)", mons, err)
+	}
+}
+
+func TestMonitorDetails(t *testing.T) {
+	srv := NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	down := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	expires := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName:       "web",
+		URL:                "https://www.example.com/",
+		Type:               uptimerobot.MonitorTypeHTTP,
+		Subtype:            uptimerobot.MonitorSubtypeHTTPS,
+		CustomUptimeRatios: []float64{99.5, 100},
+		Logs: []uptimerobot.Log{{
+			Type:     uptimerobot.LogTypeDown,
+			DateTime: uptimerobot.UptimeRobotDate(down),
+			Duration: 90 * time.Second,
+			Reason:   uptimerobot.LogReason{Code: "503", Detail: "Service Unavailable"},
+		}},
+		SSL: &uptimerobot.SSLInfo{Brand: "Let's Encrypt", Expires: expires},
+	})
+
+	mons, err := ur.GetMonitors(&uptimerobot.GetMonitorsInput{
+		CustomUptimeRatio: []int{7, 30},
+		Logs:              true,
+		SSL:               true,
+	})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(mons) != 1 {
+		t.Fatalf("Expected 1 monitor, got %+v", mons)
+	}
+	m := mons[0]
+
+	if m.CustomUptimeRatio != 99.5 || fmt.Sprint(m.CustomUptimeRatios) != "[99.5 100]" {
+		t.Errorf("Unexpected uptime ratios: %v %v", m.CustomUptimeRatio, m.CustomUptimeRatios)
+	}
+
+	if len(m.Logs) != 1 || !time.Time(m.Logs[0].DateTime).Equal(down) || m.Logs[0].Duration != 90*time.Second || m.Logs[0].Reason.Code != "503" {
+		t.Errorf("Unexpected logs: %+v", m.Logs)
+	}
+
+	if m.SSL == nil || m.SSL.Brand != "Let's Encrypt" || !m.SSL.Expires.Equal(expires) {
+		t.Errorf("Unexpected SSL info: %+v", m.SSL)
+	}
+
+	m.CustomHTTPHeaders = map[string]string{"X-Check": "uptimerobot"}
+	if _, err := ur.NewOrEditMonitor(m); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	mons, err = ur.GetMonitors(&uptimerobot.GetMonitorsInput{CustomHTTPHeaders: true})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(mons) != 1 || mons[0].CustomHTTPHeaders["X-Check"] != "uptimerobot" || mons[0].Logs != nil {
+		t.Errorf("Unexpected monitors: %+v", mons)
+	}
+}
+
+func TestLogsLimit(t *testing.T) {
+	srv := NewServer("u1234-testkey")
+	defer srv.Close()
+
+	up := time.Date(2017, 3, 1, 12, 5, 0, 0, time.UTC)
+	srv.AddMonitor(uptimerobot.Monitor{
+		FriendlyName: "web",
+		URL:          "https://www.example.com/",
+		Type:         uptimerobot.MonitorTypeHTTP,
+		// Added oldest first
+		Logs: []uptimerobot.Log{
+			{Type: uptimerobot.LogTypeDown, DateTime: uptimerobot.UptimeRobotDate(up.Add(-5 * time.Minute))},
+			{Type: uptimerobot.LogTypeUp, DateTime: uptimerobot.UptimeRobotDate(up)},
+		},
+	})
+
+	mons, err := srv.Client().GetMonitors(&uptimerobot.GetMonitorsInput{Logs: true, LogsLimit: 1})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(mons) != 1 || len(mons[0].Logs) != 1 || !time.Time(mons[0].Logs[0].DateTime).Equal(up) {
+		t.Errorf("Expected only the newest log, got %+v", mons)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv := NewServer("u1234-testkey")
+	defer srv.Close()
+	srv.PageLimit = 2
+
+	for i := 0; i < 5; i++ {
+		srv.AddMonitor(uptimerobot.Monitor{
+			FriendlyName: fmt.Sprintf("monitor %d", i),
+			URL:          fmt.Sprintf("http://%d.example.com/", i),
+			Type:         uptimerobot.MonitorTypeHTTP,
+		})
+		srv.AddAlertContact(uptimerobot.AlertContact{
+			Type:  uptimerobot.AlertContactTypeEMail,
+			Value: fmt.Sprintf("%d@example.com", i),
+		})
+	}
+
+	mons, err := srv.Client().GetMonitors(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(mons) != 5 || mons[4].FriendlyName != "monitor 4" {
+		t.Errorf("Expected all 5 monitors in order, got %+v", mons)
+	}
+
+	acs, err := srv.Client().GetAlertContacts(nil)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if len(acs) != 5 {
+		t.Errorf("Expected 5 alert contacts, got %d", len(acs))
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	srv := NewServer("u1234-testkey")
+	defer srv.Close()
+
+	ur := srv.Client()
+	ur.RetryPolicy = nil
+
+	wrongKey := uptimerobot.New("foobar")
+	wrongKey.BaseURL = srv.URL
+	if _, err := wrongKey.GetAccountDetails(); !errors.Is(err, uptimerobot.ErrorAPIKeyWrong) {
+		t.Errorf("Expected ErrorAPIKeyWrong, got: %v", err)
+	}
+
+	_, err := ur.NewAlertContact(uptimerobot.AlertContact{Type: uptimerobot.AlertContactTypeEMail, Value: "no-mail"})
+	if !errors.Is(err, uptimerobot.ErrorAlertContactValueShouldBeEMail) {
+		t.Errorf("Expected ErrorAlertContactValueShouldBeEMail, got: %v", err)
+	}
+
+	_, err = ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "kw", URL: "http://example.com/", Type: uptimerobot.MonitorTypeKeyword})
+	if !errors.Is(err, uptimerobot.ErrorMonitorKeywordTypeAndKeywordValueRequired) {
+		t.Errorf("Expected ErrorMonitorKeywordTypeAndKeywordValueRequired, got: %v", err)
+	}
+
+	if err := ur.DeleteAlertContact(42); !errors.Is(err, uptimerobot.ErrorAlertContactIDNotExists) {
+		t.Errorf("Expected ErrorAlertContactIDNotExists, got: %v", err)
+	}
+
+	acs, err := ur.GetAlertContacts(nil)
+	if err != nil || len(acs) != 0 {
+		t.Errorf("Expected no alert contacts, got %+v (%v)", acs, err)
+	}
+}
@@ -0,0 +1,237 @@
+package uptimerobottest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// apiErrors holds the error type and parameter the API reports for the error
+// codes returned by the handlers
+var apiErrors = map[uptimerobot.APIError][2]string{
+	uptimerobot.ErrorAPIKeyWrongFormat:                         {"missing_parameter", "api_key"},
+	uptimerobot.ErrorAPIKeyWrong:                               {"invalid_parameter", "api_key"},
+	uptimerobot.ErrorWrongFormat:                               {"invalid_parameter", "format"},
+	uptimerobot.ErrorNoSuchMethod:                              {"not_found", "method"},
+	uptimerobot.ErrorNoEditsFound:                              {"missing_parameter", ""},
+	uptimerobot.ErrorMonitorIDShouldBeInteger:                  {"invalid_parameter", "id"},
+	uptimerobot.ErrorMonitorIDRequired:                         {"missing_parameter", "id"},
+	uptimerobot.ErrorMonitorIDNoExists:                         {"not_found", "id"},
+	uptimerobot.ErrorMonitorURLInvalid:                         {"invalid_parameter", "url"},
+	uptimerobot.ErrorMonitorTypeInvalid:                        {"invalid_parameter", "type"},
+	uptimerobot.ErrorMonitorSubTypeInvalid:                     {"invalid_parameter", "sub_type"},
+	uptimerobot.ErrorMonitorSubTypeRequired:                    {"missing_parameter", "sub_type"},
+	uptimerobot.ErrorMonitorKeywordTypeInvalid:                 {"invalid_parameter", "keyword_type"},
+	uptimerobot.ErrorMonitorKeywordTypeAndKeywordValueRequired: {"missing_parameter", "keyword_value"},
+	uptimerobot.ErrorMonitorPortInvalid:                        {"invalid_parameter", "port"},
+	uptimerobot.ErrorMonitorFriendlyNameRequired:               {"missing_parameter", "friendly_name"},
+	uptimerobot.ErrorMonitorAlreadyExists:                      {"already_exists", ""},
+	uptimerobot.ErrorHTTPCredentialsMismatch:                   {"invalid_parameter", "http_password"},
+	uptimerobot.ErrorMonitorAlertContactsValueInvalid:          {"invalid_parameter", "alert_contacts"},
+	uptimerobot.ErrorAlertContactIDShoudBeInteger:              {"invalid_parameter", "id"},
+	uptimerobot.ErrorAlertContactIDNotExists:                   {"not_found", "id"},
+	uptimerobot.ErrorAlertContactTypeAndValueRequired:          {"missing_parameter", "value"},
+	uptimerobot.ErrorAlertContactTypeNotSupported:              {"invalid_parameter", "type"},
+	uptimerobot.ErrorAlertContactValueShouldBeEMail:            {"invalid_parameter", "value"},
+	uptimerobot.ErrorAlertContactAlreadyExists:                 {"already_exists", ""},
+	uptimerobot.ErrorMWindowIDNotExists:                        {"not_found", "mwindows"},
+	uptimerobot.ErrorMWindowValueInvalid:                       {"invalid_parameter", "value"},
+	uptimerobot.ErrorPSPIDNotExists:                            {"not_found", "id"},
+	uptimerobot.ErrorPSPValueInvalid:                           {"invalid_parameter", "monitors"},
+}
+
+func errorResponse(code uptimerobot.APIError) map[string]interface{} {
+	e, ok := apiErrors[code]
+	if !ok {
+		e = [2]string{"invalid_parameter", ""}
+	}
+
+	return map[string]interface{}{
+		"stat": "fail",
+		"error": map[string]string{
+			"type":           e[0],
+			"parameter_name": e[1],
+			"message":        code.Error(),
+		},
+	}
+}
+
+func pagination(offset, limit, total int) map[string]int {
+	return map[string]int{
+		"offset": offset,
+		"limit":  limit,
+		"total":  total,
+	}
+}
+
+// monitorJSON returns the monitor as the API reports it, the optional parts
+// are only included if requested by params
+func (s *Server) monitorJSON(m *uptimerobot.Monitor, params url.Values) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":                    m.ID,
+		"friendly_name":         m.FriendlyName,
+		"url":                   m.URL,
+		"type":                  int(m.Type),
+		"sub_type":              optionalInt(int(m.Subtype)),
+		"keyword_type":          optionalInt(int(m.KeywordType)),
+		"keyword_value":         m.KeywordValue,
+		"http_username":         m.HTTPUsername,
+		"http_password":         m.HTTPPassword,
+		"port":                  optionalInt(m.Port),
+		"interval":              m.Interval,
+		"status":                int(m.Status),
+		"all_time_uptime_ratio": formatRatio(m.AlltimeUptimeRatio),
+	}
+
+	if periods := params.Get("custom_uptime_ratios"); periods != "" {
+		ratios := []string{}
+		for i := range strings.Split(periods, "-") {
+			r := m.CustomUptimeRatio
+			if i < len(m.CustomUptimeRatios) {
+				r = m.CustomUptimeRatios[i]
+			}
+			ratios = append(ratios, formatRatio(r))
+		}
+		out["custom_uptime_ratio"] = strings.Join(ratios, "-")
+	}
+
+	if params.Get("logs") == "1" {
+		// The API returns the newest logs first, whatever order they were
+		// added in
+		sorted := append([]uptimerobot.Log{}, m.Logs...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return time.Time(sorted[i].DateTime).After(time.Time(sorted[j].DateTime))
+		})
+
+		logs := []map[string]interface{}{}
+		for i, l := range sorted {
+			if limit, _ := strconv.Atoi(params.Get("logs_limit")); limit > 0 && i >= limit {
+				break
+			}
+			jl := map[string]interface{}{
+				"type":     int(l.Type),
+				"datetime": time.Time(l.DateTime).Unix(),
+				"duration": int(l.Duration / time.Second),
+				"reason": map[string]string{
+					"code":   l.Reason.Code,
+					"detail": l.Reason.Detail,
+				},
+			}
+			if params.Get("alert_contacts") == "1" {
+				contacts := []map[string]interface{}{}
+				for _, c := range l.AlertContacts {
+					contacts = append(contacts, alertContactJSON(c))
+				}
+				jl["alert_contacts"] = contacts
+			}
+			logs = append(logs, jl)
+		}
+		out["logs"] = logs
+	}
+
+	if params.Get("response_times") == "1" {
+		times := []map[string]int64{}
+		for i, r := range m.ResponseTimes {
+			if limit, _ := strconv.Atoi(params.Get("response_times_limit")); limit > 0 && i >= limit {
+				break
+			}
+			times = append(times, map[string]int64{
+				"datetime": time.Time(r.DateTime).Unix(),
+				"value":    int64(r.Value),
+			})
+		}
+		out["response_times"] = times
+	}
+
+	if params.Get("show_monitor_alert_contacts") == "1" {
+		contacts := []map[string]interface{}{}
+		for _, c := range m.AlertContacts {
+			jc := alertContactJSON(c)
+			jc["threshold"] = c.Threshold
+			jc["recurrence"] = c.Recurrence
+			contacts = append(contacts, jc)
+		}
+		out["alert_contacts"] = contacts
+	}
+
+	if params.Get("ssl") == "1" && m.SSL != nil {
+		out["ssl"] = map[string]interface{}{
+			"brand":                 m.SSL.Brand,
+			"product":               m.SSL.Product,
+			"expires":               m.SSL.Expires.Unix(),
+			"ignore_errors":         boolInt(m.SSL.IgnoreErrors),
+			"disable_notifications": boolInt(m.SSL.DisableNotifications),
+		}
+	}
+
+	if params.Get("custom_http_headers") == "1" {
+		headers := m.CustomHTTPHeaders
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		out["custom_http_headers"] = headers
+	}
+
+	if params.Get("mwindows") == "1" {
+		windows := []map[string]interface{}{}
+		for _, id := range m.MaintenanceWindows {
+			if w, ok := s.mwindows[id]; ok {
+				windows = append(windows, mwindowJSON(w))
+			}
+		}
+		out["mwindows"] = windows
+	}
+
+	return out
+}
+
+func alertContactJSON(c uptimerobot.AlertContact) map[string]interface{} {
+	// The API reports the IDs of alert contacts as strings
+	return map[string]interface{}{
+		"id":            strconv.Itoa(c.ID),
+		"friendly_name": c.FriendlyName,
+		"type":          int(c.Type),
+		"status":        int(c.Status),
+		"value":         c.Value,
+	}
+}
+
+func mwindowJSON(w *uptimerobot.MaintenanceWindow) map[string]interface{} {
+	var start interface{} = w.Start.Format("15:04")
+	if w.Type == uptimerobot.MaintenanceWindowTypeOnce {
+		start = w.Start.Unix()
+	}
+
+	return map[string]interface{}{
+		"id":            w.ID,
+		"type":          int(w.Type),
+		"friendly_name": w.FriendlyName,
+		"start_time":    start,
+		"duration":      int(w.Duration / time.Minute),
+		"value":         joinInts(w.Days),
+		"status":        int(w.Status),
+	}
+}
+
+// optionalInt returns an empty string for unset numbers like the API does
+func optionalInt(i int) interface{} {
+	if i == 0 {
+		return ""
+	}
+	return i
+}
+
+func formatRatio(r float64) string {
+	return strconv.FormatFloat(r, 'f', 3, 64)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package watcher
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+)
+
+// Cursor is the state of the monitors as of the last poll
+type Cursor struct {
+	Polled   time.Time            `json:"polled"`
+	Monitors map[int]MonitorState `json:"monitors"`
+}
+
+// MonitorState is the state of a single monitor as of the last poll
+type MonitorState struct {
+	Status uptimerobot.MonitorStatus `json:"status"`
+	// whether the monitor was reported down or paused
+	Down   bool `json:"down"`
+	Paused bool `json:"paused"`
+	// the time of the newest log seen, older logs are not reported again
+	LastLog time.Time     `json:"lastLog"`
+	Config  MonitorConfig `json:"config"`
+}
+
+// MonitorConfig are the settings of a monitor compared to report
+// EventConfigChanged. The HTTP password is left out so it is not persisted.
+type MonitorConfig struct {
+	FriendlyName       string                         `json:"friendlyName"`
+	URL                string                         `json:"url"`
+	Type               uptimerobot.MonitorType        `json:"type"`
+	Subtype            uptimerobot.MonitorSubtype     `json:"subtype,omitempty"`
+	Port               int                            `json:"port,omitempty"`
+	KeywordType        uptimerobot.MonitorKeywordType `json:"keywordType,omitempty"`
+	KeywordValue       string                         `json:"keywordValue,omitempty"`
+	HTTPUsername       string                         `json:"httpUsername,omitempty"`
+	Interval           int                            `json:"interval"`
+	AlertContacts      []int                          `json:"alertContacts,omitempty"`
+	MaintenanceWindows []int                          `json:"maintenanceWindows,omitempty"`
+}
+
+func newMonitorState(m *uptimerobot.Monitor) MonitorState {
+	c := MonitorConfig{
+		FriendlyName:       m.FriendlyName,
+		URL:                m.URL,
+		Type:               m.Type,
+		Subtype:            m.Subtype,
+		Port:               m.Port,
+		KeywordType:        m.KeywordType,
+		KeywordValue:       m.KeywordValue,
+		HTTPUsername:       m.HTTPUsername,
+		Interval:           m.Interval,
+		MaintenanceWindows: append([]int{}, m.MaintenanceWindows...),
+	}
+	for _, ac := range m.AlertContacts {
+		c.AlertContacts = append(c.AlertContacts, ac.ID)
+	}
+	sort.Ints(c.AlertContacts)
+	sort.Ints(c.MaintenanceWindows)
+	if len(c.MaintenanceWindows) == 0 {
+		c.MaintenanceWindows = nil
+	}
+
+	return MonitorState{
+		Status: m.Status,
+		Down:   m.Status == uptimerobot.MonitorStatusSeemsDown || m.Status == uptimerobot.MonitorStatusDown,
+		Paused: m.Status == uptimerobot.MonitorStatusPaused,
+		Config: c,
+	}
+}
+
+// changes returns the names of the settings which differ
+func (c MonitorConfig) changes(other MonitorConfig) []string {
+	changes := []string{}
+	a, b := reflect.ValueOf(c), reflect.ValueOf(other)
+	for i := 0; i < a.NumField(); i++ {
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changes = append(changes, a.Type().Field(i).Name)
+		}
+	}
+	return changes
+}
+
+// Store persists the cursor of a watcher
+type Store interface {
+	// Load returns the saved cursor, nil if there is none
+	Load() (*Cursor, error)
+	Save(c *Cursor) error
+}
+
+// FileStore returns a Store keeping the cursor as JSON in the given file
+func FileStore(path string) Store {
+	return fileStore(path)
+}
+
+type fileStore string
+
+func (f fileStore) Load() (*Cursor, error) {
+	data, err := ioutil.ReadFile(string(f))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.Monitors == nil {
+		c.Monitors = map[int]MonitorState{}
+	}
+	return c, nil
+}
+
+// Save replaces the file atomically so a crash does not leave a partial
+// cursor behind
+func (f fileStore) Save(c *Cursor) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(string(f)), filepath.Base(string(f))+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
@@ -0,0 +1,369 @@
+// Package watcher polls the monitors of an account and emits events when
+// their status changes, they are created, deleted or reconfigured:
+//
+//	w := watcher.NewWatcher(ur)
+//	w.Store = watcher.FileStore("/var/lib/myapp/uptimerobot-cursor.json")
+//	w.Subscribe(func(e watcher.Event) {
+//		if e.Type == watcher.EventDown {
+//			log.Printf("%s is down", e.Name)
+//		}
+//	})
+//	err := w.Run(ctx)
+//
+// Outages are detected from the status of the monitors and from their logs,
+// so a monitor going down and recovering between two polls is still reported.
+// The state of the monitors is saved to the Store after every poll. On
+// restart the watcher continues from there, reporting the changes which
+// happened in the meantime but no event twice. Without a saved state the
+// first poll only records the current state.
+package watcher
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/internal/poll"
+)
+
+// EventType is the kind of change of a monitor
+type EventType int
+
+// Types of events
+const (
+	// the monitor went down (status seems down or down, or a down log)
+	EventDown EventType = iota + 1
+	// the monitor is up again after being down
+	EventRecovered
+	// the monitor was paused
+	EventPaused
+	// the monitor was started again after being paused
+	EventResumed
+	EventCreated
+	EventDeleted
+	// settings of the monitor changed (see Event.Changes)
+	EventConfigChanged
+)
+
+var eventTypeNames = map[EventType]string{
+	EventDown:          "down",
+	EventRecovered:     "recovered",
+	EventPaused:        "paused",
+	EventResumed:       "resumed",
+	EventCreated:       "created",
+	EventDeleted:       "deleted",
+	EventConfigChanged: "config-changed",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Event is a change of a monitor
+type Event struct {
+	Type      EventType
+	MonitorID int
+	// the friendly name of the monitor
+	Name string
+	// the monitor as returned by the poll detecting the change, nil for
+	// EventDeleted
+	Monitor *uptimerobot.Monitor
+	// the log entry the event was derived from, nil for events derived from
+	// the status or settings
+	Log *uptimerobot.Log
+	// the time of the log entry or the poll
+	Time time.Time
+	// the names of the changed settings for EventConfigChanged (Example:
+	// "URL", "Interval")
+	Changes []string
+}
+
+// Watcher polls the monitors and emits events for their changes
+type Watcher struct {
+	// the time between two polls (default: one minute)
+	Interval time.Duration
+	// the number of the newest logs fetched per monitor, changes with more
+	// logs between two polls are reported from the status only (default: 10)
+	LogsLimit int
+	// Store keeps the state of the monitors between restarts (nil keeps it in
+	// memory only)
+	Store Store
+	// Logger receives the failed polls (default: the Logger of the client, the
+	// standard logger if neither is set)
+	Logger uptimerobot.Logger
+
+	ur  *uptimerobot.UptimeRobot
+	now func() time.Time
+
+	mu          sync.Mutex
+	cursor      *Cursor
+	subscribers []func(ctx context.Context, e Event) error
+	channels    []chan Event
+}
+
+// NewWatcher creates a watcher polling the monitors with the given client
+func NewWatcher(ur *uptimerobot.UptimeRobot) *Watcher {
+	return &Watcher{
+		Interval:  time.Minute,
+		LogsLimit: 10,
+		ur:        ur,
+		now:       time.Now,
+	}
+}
+
+// Subscribe registers fn to be called for every event. The subscribers are
+// called in the order of the events by the goroutine polling the API.
+func (w *Watcher) Subscribe(fn func(Event)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, func(ctx context.Context, e Event) error {
+		fn(e)
+		return nil
+	})
+}
+
+// Events returns a channel receiving every event. Polling blocks while the
+// channel is full, it is closed when Run returns. If the context of the poll
+// is cancelled while blocked, the state is not saved and the events of the
+// poll are delivered again by the next one.
+func (w *Watcher) Events() <-chan Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan Event, 64)
+	w.channels = append(w.channels, ch)
+	w.subscribers = append(w.subscribers, func(ctx context.Context, e Event) error {
+		select {
+		case ch <- e:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return ch
+}
+
+// Run polls the monitors every Interval until the context is cancelled,
+// failed polls are logged and retried with the next one
+func (w *Watcher) Run(ctx context.Context) error {
+	defer func() {
+		w.mu.Lock()
+		for _, ch := range w.channels {
+			close(ch)
+		}
+		w.channels = nil
+		w.mu.Unlock()
+	}()
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return poll.Run(ctx, interval, poll.Logger(w.Logger, w.ur), "Watching the UptimeRobot monitors failed", func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	})
+}
+
+// Poll fetches the monitors once, passes the events of the changes since the
+// last poll to the subscribers and saves the new state to the Store. If the
+// context is cancelled while delivering, its error is returned and the state
+// is left as it was.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	if err := w.loadCursor(); err != nil {
+		return nil, err
+	}
+
+	monitors, err := w.ur.GetMonitorsContext(ctx, &uptimerobot.GetMonitorsInput{
+		Logs:                     true,
+		LogsLimit:                w.LogsLimit,
+		ShowMonitorAlertContacts: true,
+		MaintenanceWindows:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	events, cursor := diff(w.cursor, monitors, w.now())
+	subscribers := append([]func(context.Context, Event) error{}, w.subscribers...)
+	w.mu.Unlock()
+
+	for _, e := range events {
+		for _, fn := range subscribers {
+			if err := fn(ctx, e); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The state is saved once the events were delivered, an interrupted
+	// poll is repeated by the next one or after a restart
+	w.mu.Lock()
+	w.cursor = cursor
+	w.mu.Unlock()
+	if w.Store != nil {
+		if err := w.Store.Save(cursor); err != nil {
+			return events, err
+		}
+	}
+	return events, nil
+}
+
+// loadCursor reads the state from the Store on the first poll
+func (w *Watcher) loadCursor() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cursor != nil || w.Store == nil {
+		return nil
+	}
+
+	cursor, err := w.Store.Load()
+	if err != nil {
+		return err
+	}
+	w.cursor = cursor
+	return nil
+}
+
+// diff compares the monitors with the state of the last poll and returns the
+// events and the new state. Without a previous state no events are returned.
+func diff(prev *Cursor, monitors []uptimerobot.Monitor, now time.Time) ([]Event, *Cursor) {
+	next := &Cursor{Polled: now, Monitors: map[int]MonitorState{}}
+	events := []Event{}
+
+	for i := range monitors {
+		m := &monitors[i]
+		state := newMonitorState(m)
+
+		old, known := MonitorState{}, false
+		if prev != nil {
+			old, known = prev.Monitors[m.ID]
+		}
+
+		switch {
+		case prev == nil:
+			// First poll: record the current state only
+		case !known:
+			events = append(events, Event{Type: EventCreated, MonitorID: m.ID, Name: m.FriendlyName, Monitor: m, Time: now})
+		default:
+			state.Down, state.Paused, state.LastLog = old.Down, old.Paused, old.LastLog
+			if changes := old.Config.changes(state.Config); len(changes) > 0 {
+				events = append(events, Event{Type: EventConfigChanged, MonitorID: m.ID, Name: m.FriendlyName, Monitor: m, Time: now, Changes: changes})
+			}
+			events = append(events, state.apply(m, now)...)
+		}
+
+		state.LastLog = latestLog(m.Logs, state.LastLog)
+		next.Monitors[m.ID] = state
+	}
+
+	if prev != nil {
+		deleted := []int{}
+		for id := range prev.Monitors {
+			if _, ok := next.Monitors[id]; !ok {
+				deleted = append(deleted, id)
+			}
+		}
+		sort.Ints(deleted)
+		for _, id := range deleted {
+			events = append(events, Event{Type: EventDeleted, MonitorID: id, Name: prev.Monitors[id].Config.FriendlyName, Time: now})
+		}
+	}
+	return events, next
+}
+
+// apply derives the events from the logs newer than the last poll and the
+// current status of the monitor and updates the state accordingly
+func (s *MonitorState) apply(m *uptimerobot.Monitor, now time.Time) []Event {
+	events := []Event{}
+	emit := func(t EventType, l *uptimerobot.Log) {
+		e := Event{Type: t, MonitorID: m.ID, Name: m.FriendlyName, Monitor: m, Log: l, Time: now}
+		if l != nil {
+			e.Time = time.Time(l.DateTime)
+		}
+		events = append(events, e)
+	}
+
+	logs := append([]uptimerobot.Log{}, m.Logs...)
+	sort.SliceStable(logs, func(i, j int) bool {
+		return time.Time(logs[i].DateTime).Before(time.Time(logs[j].DateTime))
+	})
+	for i := range logs {
+		l := &logs[i]
+		if !time.Time(l.DateTime).After(s.LastLog) {
+			continue
+		}
+
+		switch l.Type {
+		case uptimerobot.LogTypeDown:
+			if !s.Down {
+				emit(EventDown, l)
+			}
+			s.Down = true
+		case uptimerobot.LogTypeUp:
+			if s.Paused {
+				emit(EventResumed, l)
+			}
+			if s.Down {
+				emit(EventRecovered, l)
+			}
+			s.Down, s.Paused = false, false
+		case uptimerobot.LogTypeStarted:
+			if s.Paused {
+				emit(EventResumed, l)
+			}
+			s.Paused = false
+		case uptimerobot.LogTypePaused:
+			if !s.Paused {
+				emit(EventPaused, l)
+			}
+			s.Down, s.Paused = false, true
+		}
+	}
+
+	// The status catches changes without logs, like seems down, and those
+	// beyond the fetched logs
+	switch m.Status {
+	case uptimerobot.MonitorStatusSeemsDown, uptimerobot.MonitorStatusDown:
+		if s.Paused {
+			emit(EventResumed, nil)
+		}
+		if !s.Down {
+			emit(EventDown, nil)
+		}
+		s.Down, s.Paused = true, false
+	case uptimerobot.MonitorStatusUp:
+		if s.Paused {
+			emit(EventResumed, nil)
+		}
+		if s.Down {
+			emit(EventRecovered, nil)
+		}
+		s.Down, s.Paused = false, false
+	case uptimerobot.MonitorStatusPaused:
+		if !s.Paused {
+			emit(EventPaused, nil)
+		}
+		s.Down, s.Paused = false, true
+	}
+	s.Status = m.Status
+	return events
+}
+
+func latestLog(logs []uptimerobot.Log, latest time.Time) time.Time {
+	for _, l := range logs {
+		if t := time.Time(l.DateTime); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
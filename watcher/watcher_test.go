@@ -0,0 +1,275 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	uptimerobot "github.com/Jimdo/uptimerobot-api"
+	"github.com/Jimdo/uptimerobot-api/uptimerobottest"
+)
+
+var start = time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+func logAt(typ uptimerobot.LogType, minute int) uptimerobot.Log {
+	return uptimerobot.Log{Type: typ, DateTime: uptimerobot.UptimeRobotDate(start.Add(time.Duration(minute) * time.Minute))}
+}
+
+func eventTypes(events []Event) []EventType {
+	types := []EventType{}
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestDiff(t *testing.T) {
+	web := uptimerobot.Monitor{ID: 1, FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, Status: uptimerobot.MonitorStatusUp, Logs: []uptimerobot.Log{
+		logAt(uptimerobot.LogTypeStarted, 0),
+	}}
+
+	events, cursor := diff(nil, []uptimerobot.Monitor{web}, start.Add(time.Minute))
+	if len(events) != 0 {
+		t.Fatalf("Expected no events on the first poll, got %+v", events)
+	}
+
+	for _, step := range []struct {
+		name     string
+		status   uptimerobot.MonitorStatus
+		logs     []uptimerobot.Log
+		expected []EventType
+	}{
+		{"seems down", uptimerobot.MonitorStatusSeemsDown, nil, []EventType{EventDown}},
+		{"confirmed down", uptimerobot.MonitorStatusDown, []uptimerobot.Log{logAt(uptimerobot.LogTypeDown, 2)}, []EventType{}},
+		{"recovered", uptimerobot.MonitorStatusUp, []uptimerobot.Log{logAt(uptimerobot.LogTypeUp, 3), logAt(uptimerobot.LogTypeDown, 2)}, []EventType{EventRecovered}},
+		{"flap between polls", uptimerobot.MonitorStatusUp, []uptimerobot.Log{logAt(uptimerobot.LogTypeUp, 5), logAt(uptimerobot.LogTypeDown, 4), logAt(uptimerobot.LogTypeUp, 3)}, []EventType{EventDown, EventRecovered}},
+		{"unchanged", uptimerobot.MonitorStatusUp, []uptimerobot.Log{logAt(uptimerobot.LogTypeUp, 5)}, []EventType{}},
+		{"paused", uptimerobot.MonitorStatusPaused, []uptimerobot.Log{logAt(uptimerobot.LogTypePaused, 6)}, []EventType{EventPaused}},
+		{"resumed and down", uptimerobot.MonitorStatusDown, []uptimerobot.Log{logAt(uptimerobot.LogTypeDown, 8), logAt(uptimerobot.LogTypeStarted, 7)}, []EventType{EventResumed, EventDown}},
+	} {
+		web.Status, web.Logs = step.status, step.logs
+		events, cursor = diff(cursor, []uptimerobot.Monitor{web}, start.Add(10*time.Minute))
+
+		if types := eventTypes(events); !reflect.DeepEqual(types, step.expected) {
+			t.Errorf("%s: Expected %v, got %v", step.name, step.expected, types)
+		}
+	}
+
+	// Events derived from logs carry them
+	if e := events[1]; e.Log == nil || !e.Time.Equal(start.Add(8*time.Minute)) || e.Monitor.ID != 1 || e.Name != "web" {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+	ur := srv.Client()
+
+	web := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "web", URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP, Interval: 300, Status: uptimerobot.MonitorStatusUp})
+	shop := srv.AddMonitor(uptimerobot.Monitor{FriendlyName: "shop", URL: "http://shop.example.com/", Type: uptimerobot.MonitorTypeHTTP, Interval: 300, Status: uptimerobot.MonitorStatusUp})
+
+	store := FileStore(filepath.Join(t.TempDir(), "cursor.json"))
+	w := NewWatcher(ur)
+	w.Store = store
+
+	subscribed := []Event{}
+	w.Subscribe(func(e Event) { subscribed = append(subscribed, e) })
+	ch := w.Events()
+
+	ctx := context.Background()
+	if events, err := w.Poll(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("Expected no events on the first poll, got %+v (%v)", events, err)
+	}
+
+	api, err := ur.NewOrEditMonitor(uptimerobot.Monitor{FriendlyName: "api", URL: "http://api.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	edited := web
+	edited.URL = "https://www.example.com/"
+	edited.Interval = 60
+	if _, err := ur.NewOrEditMonitor(edited); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	if _, err := ur.PauseMonitor(shop.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	events, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	expected := []EventType{EventConfigChanged, EventPaused, EventCreated}
+	if types := eventTypes(events); !reflect.DeepEqual(types, expected) {
+		t.Fatalf("Expected %v, got %+v", expected, events)
+	}
+	if e := events[0]; e.MonitorID != web.ID || !reflect.DeepEqual(e.Changes, []string{"URL", "Interval"}) {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+	if e := events[2]; e.MonitorID != api.ID || e.Monitor == nil || e.Monitor.FriendlyName != "api" {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+
+	if !reflect.DeepEqual(subscribed, events) {
+		t.Errorf("Expected the subscriber to receive the events, got %+v", subscribed)
+	}
+	for i := range events {
+		if e := <-ch; e.Type != events[i].Type || e.MonitorID != events[i].MonitorID {
+			t.Errorf("Expected %+v on the channel, got %+v", events[i], e)
+		}
+	}
+
+	// A restarted watcher continues from the saved state
+	if err := ur.DeleteMonitor(api.ID); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	w = NewWatcher(ur)
+	w.Store = store
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(events) != 1 || events[0].Type != EventDeleted || events[0].MonitorID != api.ID || events[0].Name != "api" || events[0].Monitor != nil {
+		t.Errorf("Expected only the deletion, got %+v", events)
+	}
+
+	if events, err := w.Poll(ctx); err != nil || len(events) != 0 {
+		t.Errorf("Expected no events without changes, got %+v (%v)", events, err)
+	}
+}
+
+func TestRunClosesChannels(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	w := NewWatcher(srv.Client())
+	ch := w.Events()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.Run(ctx); err != context.Canceled {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+
+	if _, ok := <-ch; ok {
+		t.Errorf("Expected the channel to be closed")
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	if s := EventRecovered.String(); s != "recovered" {
+		t.Errorf("Unexpected name: %s", s)
+	}
+	if s := EventType(0).String(); s != "unknown" {
+		t.Errorf("Unexpected name: %s", s)
+	}
+}
+
+// memoryStore counts the saves of the cursor
+type memoryStore struct {
+	cursor *Cursor
+	saves  int
+}
+
+func (m *memoryStore) Load() (*Cursor, error) { return m.cursor, nil }
+
+func (m *memoryStore) Save(c *Cursor) error {
+	m.cursor = c
+	m.saves++
+	return nil
+}
+
+func TestPollKeepsStateWhenCancelled(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	store := &memoryStore{}
+	w := NewWatcher(srv.Client())
+	w.Store = store
+	ch := w.Events()
+
+	if _, err := w.Poll(context.Background()); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+
+	// One event more than the channel buffers
+	for i := 0; i <= cap(ch); i++ {
+		srv.AddMonitor(uptimerobot.Monitor{FriendlyName: fmt.Sprintf("web %d", i), URL: "http://www.example.com/", Type: uptimerobot.MonitorTypeHTTP})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := w.Poll(ctx)
+		done <- err
+	}()
+
+	for len(ch) < cap(ch) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Expected the context error, got %v", err)
+	}
+	if store.saves != 1 || len(store.cursor.Monitors) != 0 {
+		t.Errorf("Expected the state of the interrupted poll not to be saved, got %d saves of %+v", store.saves, store.cursor)
+	}
+
+	// The next poll delivers the events again
+	for len(ch) > 0 {
+		<-ch
+	}
+	go func() {
+		for range ch {
+		}
+	}()
+	events, err := w.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	if len(events) != cap(ch)+1 {
+		t.Errorf("Expected %d events, got %d", cap(ch)+1, len(events))
+	}
+}
+
+// cancelLogger records the failure and stops the watcher
+type cancelLogger struct {
+	cancel context.CancelFunc
+	msgs   []string
+}
+
+func (l *cancelLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {}
+
+func (l *cancelLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	l.msgs = append(l.msgs, msg)
+	l.cancel()
+}
+
+func TestRunLogsFailedPolls(t *testing.T) {
+	srv := uptimerobottest.NewServer("u1234-testkey")
+	defer srv.Close()
+
+	ur := uptimerobot.New("u1234-wrongkey")
+	ur.BaseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &cancelLogger{cancel: cancel}
+	w := NewWatcher(ur)
+	w.Logger = l
+
+	if err := w.Run(ctx); err != context.Canceled {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+	if len(l.msgs) != 1 || l.msgs[0] != "Watching the UptimeRobot monitors failed" {
+		t.Errorf("Expected the failed poll to be logged, got %q", l.msgs)
+	}
+}
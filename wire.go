@@ -0,0 +1,102 @@
+package uptimerobot
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// flexInt decodes the numbers of the API which are sent as JSON numbers,
+// strings, empty strings or null depending on the method and field
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(in []byte) error {
+	s := rawString(in)
+	if s == "" || s == "null" {
+		*i = 0
+		return nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*i = flexInt(v)
+	return nil
+}
+
+// flexFloat is like flexInt for floating point numbers
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(in []byte) error {
+	s := rawString(in)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexFloat(v)
+	return nil
+}
+
+// rawString returns the content of a JSON string or the raw JSON of any other
+// value
+func rawString(in json.RawMessage) string {
+	in = bytes.TrimSpace(in)
+	if len(in) > 0 && in[0] == '"' {
+		var s string
+		if err := json.Unmarshal(in, &s); err == nil {
+			return s
+		}
+	}
+	return string(in)
+}
+
+// epoch converts the Unix timestamps of the API
+func epoch(sec flexInt) UptimeRobotDate {
+	return UptimeRobotDate(time.Unix(int64(sec), 0).UTC())
+}
+
+// parseIntList is the inverse of buildIntList
+func parseIntList(in string) ([]int, error) {
+	out := []int{}
+	if in == "" {
+		return out, nil
+	}
+
+	for _, s := range strings.Split(in, "-") {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+// parseFloatList parses a dash separated list of numbers as used for multiple
+// uptime ratios ("99.98-100.00")
+func parseFloatList(in string) []float64 {
+	out := []float64{}
+	for _, s := range strings.Split(in, "-") {
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsInt(list []int, i int) bool {
+	for _, v := range list {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package uptimerobot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMonitorJSON(t *testing.T) {
+	// The API sends empty strings for unset numbers, quoted IDs for alert
+	// contacts and an empty array for missing headers
+	in := `{"id":777,"friendly_name":"web","url":"https://www.example.com/","type":1,"sub_type":"","keyword_type":null,"port":"","interval":300,"status":2,
+		"all_time_uptime_ratio":"99.982","custom_uptime_ratio":"100.000-99.500","custom_http_headers":[],
+		"alert_contacts":[{"id":"0993765","type":2,"value":"ops@example.com","threshold":0,"recurrence":0}],
+		"logs":[{"type":1,"datetime":1488369600,"duration":90,"reason":{"code":503,"detail":"Service Unavailable"}}]}`
+
+	var jm monitorJSON
+	if err := json.Unmarshal([]byte(in), &jm); err != nil {
+		t.Fatalf("Test errored: %s", err)
+	}
+	m := jm.monitor()
+
+	if m.ID != 777 || m.Subtype != MonitorSubtypeHTTP || m.KeywordType != MonitorKeywordTypeExists || m.Port != 0 || m.Interval != 300 || m.Status != MonitorStatusUp {
+		t.Errorf("Unexpected monitor: %+v", m)
+	}
+
+	if m.AlltimeUptimeRatio != 99.982 || m.CustomUptimeRatio != 100 || len(m.CustomUptimeRatios) != 2 {
+		t.Errorf("Unexpected uptime ratios: %v %v %v", m.AlltimeUptimeRatio, m.CustomUptimeRatio, m.CustomUptimeRatios)
+	}
+
+	if m.CustomHTTPHeaders != nil {
+		t.Errorf("Expected no custom headers, got %v", m.CustomHTTPHeaders)
+	}
+
+	if len(m.AlertContacts) != 1 || m.AlertContacts[0].ID != 993765 {
+		t.Errorf("Unexpected alert contacts: %+v", m.AlertContacts)
+	}
+
+	if len(m.Logs) != 1 || m.Logs[0].Reason.Code != "503" || m.Logs[0].Duration.Seconds() != 90 {
+		t.Errorf("Unexpected logs: %+v", m.Logs)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		method, typ, param string
+		code               APIError
+	}{
+		{"getMonitors", "invalid_parameter", "api_key", ErrorAPIKeyWrong},
+		{"editMonitor", "not_found", "id", ErrorMonitorIDNoExists},
+		{"deleteAlertContact", "not_found", "id", ErrorAlertContactIDNotExists},
+		{"newAlertContact", "already_exists", "", ErrorAlertContactAlreadyExists},
+		{"editMWindow", "invalid_parameter", "start_time", ErrorMWindowValueInvalid},
+		{"getPSPs", "not_found", "id", ErrorPSPIDNotExists},
+		{"getAccountDetails", "internal_error", "", 0},
+	}
+
+	for _, tt := range tests {
+		if code := errorCode(tt.method, tt.typ, tt.param); code != tt.code {
+			t.Errorf("%s %s %s: expected %d, got %d", tt.method, tt.typ, tt.param, tt.code, code)
+		}
+	}
+}